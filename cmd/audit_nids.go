@@ -0,0 +1,99 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/sls"
+	sls_client "github.com/Cray-HPE/hms-sls/pkg/sls-client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// auditNIDsCmd represents the audit-nids command
+var auditNIDsCmd = &cobra.Command{
+	Use:   "audit-nids",
+	Args:  cobra.NoArgs,
+	Short: "Audit the NIDs and aliases of all nodes stored within SLS.",
+	Long: `Audit the NIDs and aliases of all nodes stored within SLS.
+
+The NIDs of nodes of all classes (River, Hill, and Mountain) are checked to be
+unique. The aliases of compute nodes are checked to be unique, and to match
+their NID. Any gaps between the lowest and highest compute node NIDs are also
+reported.
+
+This command exits with a non-zero exit code if duplicate NIDs, duplicate
+aliases, or mismatched aliases are found.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Initialize the global viper
+		v := viper.GetViper()
+		v.BindPFlags(cmd.Flags())
+
+		ctx := setupContext()
+		token := getAPIToken()
+
+		aliasFormat := v.GetString("compute-alias-format")
+		if err := (configs.NIDAssignment{AliasFormat: aliasFormat}).Validate(); err != nil {
+			log.Fatal("Error: ", err)
+		}
+
+		slsURL := v.GetString("sls-url")
+		slsClient := sls_client.NewSLSClient(slsURL, newHTTPClient().StandardClient(), "").WithAPIToken(token)
+
+		log.Printf("Retrieving current SLS state from %s\n", slsURL)
+		currentSLSState, err := slsClient.GetDumpState(ctx)
+		if err != nil {
+			log.Fatal("Error: ", err)
+		}
+
+		report, err := sls.AuditNIDs(currentSLSState.Hardware, aliasFormat)
+		if err != nil {
+			log.Fatal("Error: ", err)
+		}
+
+		summary := report.Summary()
+		if len(summary) == 0 {
+			log.Println("No NID issues found")
+		}
+		for _, line := range summary {
+			log.Println(line)
+		}
+
+		if report.HasProblems() {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(auditNIDsCmd)
+
+	auditNIDsCmd.Flags().SortFlags = false
+
+	auditNIDsCmd.Flags().String("compute-alias-format", "", "Format of the alias given to compute nodes formatted with its NID (default nid%06d)")
+	auditNIDsCmd.Flags().String("sls-url", "https://api-gw-service-nmn.local/apis/sls", "Advanced option: URL to System Layout Service (SLS)")
+}
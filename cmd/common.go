@@ -0,0 +1,221 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package cmd
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/internal/engine"
+	"github.com/Cray-HPE/hardware-topology-assistant/internal/report"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/diff"
+	"github.com/hashicorp/go-retryablehttp"
+	"gopkg.in/yaml.v2"
+)
+
+// getAPIToken retrieves the API token used to talk to CSM services from the environment.
+func getAPIToken() string {
+	token := os.Getenv("TOKEN")
+	if token == "" {
+		log.Fatal("Error environment variable TOKEN was not set")
+	}
+
+	return token
+}
+
+// newHTTPClient builds the HTTP client used to talk to CSM services.
+func newHTTPClient() *retryablehttp.Client {
+	httpClient := retryablehttp.NewClient()
+	httpClient.HTTPClient.Transport = &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+
+	return httpClient
+}
+
+// readAddressingPlan reads in the addressing plan for new cabinet subnets. If no file is given, then the default
+// addressing plan is used for all networks.
+func readAddressingPlan(addressingPlanFile string) configs.AddressingPlan {
+	var addressingPlan configs.AddressingPlan
+	if addressingPlanFile == "" {
+		log.Printf("No addressing plan file provided, new cabinet subnets will use the default addressing plan.\n")
+		return addressingPlan
+	}
+
+	log.Printf("Using addressing plan file at %s\n", addressingPlanFile)
+	addressingPlanRaw, err := ioutil.ReadFile(addressingPlanFile)
+	if err != nil {
+		log.Fatal("Error: ", err)
+	}
+
+	if err := yaml.Unmarshal(addressingPlanRaw, &addressingPlan); err != nil {
+		log.Fatal("Error: ", err)
+	}
+
+	return addressingPlan
+}
+
+// readApplicationNetworkPolicy will read in the networks application nodes need IP reservations in. If no file is
+// provided, then the default policy is used.
+func readApplicationNetworkPolicy(applicationNetworkPolicyFile string) configs.ApplicationNetworkPolicy {
+	applicationNetworkPolicy := configs.DefaultApplicationNetworkPolicy
+	if applicationNetworkPolicyFile == "" {
+		log.Printf("No application network policy file provided, UANs will be given IPs on the CAN and CHN.\n")
+	} else {
+		log.Printf("Using application network policy file at %s\n", applicationNetworkPolicyFile)
+		applicationNetworkPolicyRaw, err := ioutil.ReadFile(applicationNetworkPolicyFile)
+		if err != nil {
+			log.Fatal("Error: ", err)
+		}
+
+		applicationNetworkPolicy = configs.ApplicationNetworkPolicy{}
+		if err := yaml.Unmarshal(applicationNetworkPolicyRaw, &applicationNetworkPolicy); err != nil {
+			log.Fatal("Error: ", err)
+		}
+	}
+
+	if err := applicationNetworkPolicy.Validate(); err != nil {
+		log.Fatal("Error: ", err)
+	}
+
+	return applicationNetworkPolicy
+}
+
+// setupLogDirectory creates the directory to persist data from this run like logs and backups, and sets up the log
+// package to write to both the console and a log file within it. The log file needs to be closed by the caller.
+func setupLogDirectory(logBaseDirectory string, console io.Writer) (string, *os.File) {
+	timestamp := strings.Replace(time.Now().UTC().Format(time.RFC3339), ":", "-", -1)
+	logDirectory := path.Join(logBaseDirectory, fmt.Sprintf("hardware-topology-assistant_%s", timestamp))
+	log.Printf("Log directory is at %s", logDirectory)
+	if err := os.MkdirAll(logDirectory, 0700); err != nil {
+		log.Fatalf("Failed to create log directory at %s due to: %s", logDirectory, err)
+	}
+
+	// Setup the log package to write to both stdout and a log file
+	logFilePath := path.Join(logDirectory, "hardware-topology-assistant.log")
+	logFile, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0600)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logWriter := io.MultiWriter(console, logFile)
+	log.SetOutput(logWriter)
+
+	return logDirectory, logFile
+}
+
+// writeJSONFile writes the given data as indented JSON to a file.
+func writeJSONFile(file string, data interface{}) {
+	dataRaw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(file, dataRaw, 0600); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// isTerminal determines if the file is a terminal, such as stdout when it has not been redirected.
+func isTerminal(file *os.File) bool {
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fileInfo.Mode()&os.ModeCharDevice != 0
+}
+
+// writeDiff saves a unified diff to a file in the log directory, and prints it to the console. The diff is colorized
+// when the console is a terminal. Nothing is done for an empty diff.
+func writeDiff(console *os.File, logDirectory, fileName, unifiedDiff string) error {
+	if unifiedDiff == "" {
+		return nil
+	}
+
+	diffFile := path.Join(logDirectory, fileName)
+	if err := ioutil.WriteFile(diffFile, []byte(unifiedDiff), 0600); err != nil {
+		return err
+	}
+	log.Printf("Diff saved to %s\n", diffFile)
+
+	if isTerminal(console) {
+		unifiedDiff = diff.Colorize(unifiedDiff)
+	}
+	fmt.Fprint(console, unifiedDiff)
+	return nil
+}
+
+// Exit codes used when the topology engine fails, so automation can tell the reason apart without parsing the log.
+const (
+	exitCodeError            = 1
+	exitCodeRefused          = 2
+	exitCodeNetworkExhausted = 3
+	exitCodeInvalidInput     = 4
+)
+
+// exitCodeForError determines the exit code for an error returned by the topology engine.
+func exitCodeForError(err error) int {
+	var inputErr *engine.InputError
+	switch {
+	case errors.Is(err, engine.ErrHardwareRemoved), errors.Is(err, engine.ErrHardwareDiffers):
+		return exitCodeRefused
+	case errors.Is(err, engine.ErrNetworkExhausted), errors.Is(err, engine.ErrSubnetExhausted):
+		return exitCodeNetworkExhausted
+	case errors.As(err, &inputErr):
+		return exitCodeInvalidInput
+	default:
+		return exitCodeError
+	}
+}
+
+// fatalEngineError logs the error returned by the topology engine, and exits with the exit code for it.
+func fatalEngineError(err error) {
+	log.Print("Error: ", err)
+	os.Exit(exitCodeForError(err))
+}
+
+// fatalWithReport marks the report as failed and writes it out when an output format was requested, then logs the
+// error and exits with the exit code for it. This keeps a report of the changes applied so far when a run fails after
+// the topology engine has determined the changes.
+func fatalWithReport(changeReport *report.Report, outputFormat report.Format, err error) {
+	if outputFormat != "" {
+		changeReport.SetError(err)
+		if err := changeReport.Write(os.Stdout, outputFormat); err != nil {
+			log.Print("Error: ", err)
+		}
+	}
+
+	fatalEngineError(err)
+}
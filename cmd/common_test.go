@@ -0,0 +1,62 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/internal/engine"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/ipam"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExitCodeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ExitCodeTestSuite) TestExitCodeForError() {
+	tests := []struct {
+		name     string
+		err      error
+		exitCode int
+	}{
+		{"generic error", errors.New("unable to contact SLS"), exitCodeError},
+		{"hardware removed", &engine.HardwareRemovedError{}, exitCodeRefused},
+		{"hardware differs", fmt.Errorf("engine: %w", &engine.HardwareDiffersError{}), exitCodeRefused},
+		{"network exhausted", fmt.Errorf("unable to allocate subnet for cabinet (x3001) in network (HMN_RVR): %w", ipam.ErrNetworkExhausted), exitCodeNetworkExhausted},
+		{"VLAN range exhausted", fmt.Errorf("failed to allocate VLAN for cabinet subnet (cabinet_3001), the VLAN range 1513-1769 is full: %w", engine.ErrNetworkExhausted), exitCodeNetworkExhausted},
+		{"subnet exhausted", fmt.Errorf("unable to allocate IP: %w", engine.ErrSubnetExhausted), exitCodeNetworkExhausted},
+		{"invalid input", &engine.InputError{Err: errors.New("duplicate NID")}, exitCodeInvalidInput},
+		{"invalid override", &engine.InputError{Err: &ipam.OverrideError{Err: errors.New("VLAN (1513) is already in use within network (HMN_RVR)")}}, exitCodeInvalidInput},
+	}
+
+	for _, test := range tests {
+		suite.Equal(test.exitCode, exitCodeForError(test.err), test.name)
+	}
+}
+
+func TestExitCodeTestSuite(t *testing.T) {
+	suite.Run(t, new(ExitCodeTestSuite))
+}
@@ -0,0 +1,122 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/ipam"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/sls"
+	sls_client "github.com/Cray-HPE/hms-sls/pkg/sls-client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ipamReportCmd represents the ipam report command
+var ipamReportCmd = &cobra.Command{
+	Use:   "report",
+	Args:  cobra.NoArgs,
+	Short: "Report the IP address utilization of the SLS networks, and forecast remaining capacity.",
+	Long: `Report the IP address utilization of the SLS networks, and forecast remaining capacity.
+
+For each subnet of each network the following is shown:
+- TOTAL: Number of usable IPs in the subnet
+- USED: Number of IPs used by the gateway and IP reservations
+- STATIC FREE: Number of unused IPs before the start of the DHCP range
+- DHCP POOL: Number of IPs in the DHCP range
+
+The report also forecasts how many more river cabinets can be added before the
+HMN_RVR or NMN_RVR networks run out of space for cabinet subnets, and how many
+more application nodes of each SubRole can be added before one of their subnets
+needs its static range expanded or runs out of IPs. Cabinet subnets are sized by
+the addressing plan, which defaults to /22 subnets. The subnets of application
+nodes are taken from the application network policy, which defaults to the
+bootstrap_dhcp subnet of the CAN and CHN networks for UANs.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Initialize the global viper
+		v := viper.GetViper()
+		v.BindPFlags(cmd.Flags())
+
+		ctx := setupContext()
+		token := getAPIToken()
+
+		addressingPlan := readAddressingPlan(v.GetString("addressing-plan"))
+		applicationNetworkPolicy := readApplicationNetworkPolicy(v.GetString("application-network-policy"))
+
+		slsURL := v.GetString("sls-url")
+		slsClient := sls_client.NewSLSClient(slsURL, newHTTPClient().StandardClient(), "").WithAPIToken(token)
+
+		log.Printf("Retrieving current SLS state from %s\n", slsURL)
+		currentSLSState, err := slsClient.GetDumpState(ctx)
+		if err != nil {
+			log.Fatal("Error: ", err)
+		}
+
+		networks, err := sls.DecodeAllNetworkExtraProperties(currentSLSState.Networks)
+		if err != nil {
+			log.Fatal("Error: ", err)
+		}
+
+		report, err := ipam.BuildUtilizationReport(networks, addressingPlan, applicationNetworkPolicy)
+		if err != nil {
+			log.Fatal("Error: ", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NETWORK\tSUBNET\tCIDR\tTOTAL\tUSED\tSTATIC FREE\tDHCP POOL")
+		for _, network := range report.Networks {
+			fmt.Fprintf(w, "%s\t\t%s\t\t\t\t\n", network.Name, network.CIDR)
+			for _, subnet := range network.Subnets {
+				fmt.Fprintf(w, "\t%s\t%s\t%d\t%d\t%d\t%d\n", subnet.Name, subnet.CIDR, subnet.Total, subnet.Used, subnet.StaticFree, subnet.DHCPPool)
+			}
+		}
+		w.Flush()
+
+		fmt.Println()
+		fmt.Println("Capacity forecast:")
+		for _, network := range report.Networks {
+			if network.Name == "HMN_RVR" || network.Name == "NMN_RVR" {
+				fmt.Printf("  %s: %d available cabinet subnets (/%d)\n", network.Name, network.AvailableCabinetSubnets, addressingPlan.ForNetwork(network.Name).PrefixLength)
+			}
+		}
+		fmt.Printf("  River cabinets that can be added: %d\n", report.RiverCabinetsRemaining)
+		for _, applicationNodes := range report.ApplicationNodes {
+			fmt.Printf("  %s application nodes that can be added before a static range needs to be expanded: %d\n", applicationNodes.SubRole, applicationNodes.RemainingInStaticRange)
+			fmt.Printf("  %s application nodes that can be added before a subnet is full: %d\n", applicationNodes.SubRole, applicationNodes.Remaining)
+		}
+	},
+}
+
+func init() {
+	ipamCmd.AddCommand(ipamReportCmd)
+
+	ipamReportCmd.Flags().SortFlags = false
+
+	ipamReportCmd.Flags().String("addressing-plan", "", "YAML to control the prefix length of cabinet subnets for each network used to forecast remaining cabinets. By default cabinet subnets are /22 networks")
+	ipamReportCmd.Flags().String("application-network-policy", "", "YAML to control which networks and subnets application nodes need IPs in, keyed by HSM SubRole. By default UANs are given IPs in the bootstrap_dhcp subnet of the CAN and CHN")
+	ipamReportCmd.Flags().String("sls-url", "https://api-gw-service-nmn.local/apis/sls", "Advanced option: URL to System Layout Service (SLS)")
+}
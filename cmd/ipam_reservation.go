@@ -0,0 +1,221 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/ipam"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/sls"
+	sls_client "github.com/Cray-HPE/hms-sls/pkg/sls-client"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ipamReserveCmd represents the ipam reserve command
+var ipamReserveCmd = &cobra.Command{
+	Use:   "reserve",
+	Args:  cobra.NoArgs,
+	Short: "Reserve an IP address within a subnet of a SLS network.",
+	Long: `Reserve an IP address within a subnet of a SLS network.
+
+The next available IP address in the static IP range of the subnet is reserved
+with the given name and optional comment, such as the xname of the device using
+the IP. If the static IP range has no free IP addresses, then it is expanded by
+one IP address by moving the start of the DHCP range.
+
+For example to reserve an IP address for a customer edge device VIP:
+    hardware-topology-assistant ipam reserve --network CAN --subnet bootstrap_dhcp --name customer-edge-vip
+
+The existing and modified SLS network are saved to the log directory.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Initialize the global viper
+		v := viper.GetViper()
+		v.BindPFlags(cmd.Flags())
+
+		name := v.GetString("name")
+		comment := v.GetString("comment")
+
+		modifySLSSubnet(v, func(networkName string, slsSubnet *sls_common.IPV4Subnet) (bool, error) {
+			ipReservation, expanded, err := ipam.ReserveIP(slsSubnet, name, comment)
+			if err != nil {
+				return false, fmt.Errorf("unable to reserve IP for (%s) in subnet (%s) in network (%s): %w", name, slsSubnet.Name, networkName, err)
+			}
+
+			if ipReservation.Name == "" {
+				log.Printf("IP reservation %s already exists in subnet %s in network %s\n", name, slsSubnet.Name, networkName)
+				return false, nil
+			}
+
+			if expanded {
+				log.Printf("The static IP address range of subnet %s in network %s has been expanded, DHCP range now starts at %s\n", slsSubnet.Name, networkName, slsSubnet.DHCPStart)
+			}
+			log.Printf("Reserved IP %s for %s in subnet %s in network %s\n", ipReservation.IPAddress, name, slsSubnet.Name, networkName)
+			return true, nil
+		})
+	},
+}
+
+// ipamReleaseCmd represents the ipam release command
+var ipamReleaseCmd = &cobra.Command{
+	Use:   "release",
+	Args:  cobra.NoArgs,
+	Short: "Release an IP address reservation within a subnet of a SLS network.",
+	Long: `Release an IP address reservation within a subnet of a SLS network.
+
+The IP reservation with the given name is removed from the subnet. The static IP
+range of the subnet is left unchanged.
+
+For example:
+    hardware-topology-assistant ipam release --network CAN --subnet bootstrap_dhcp --name customer-edge-vip
+
+The existing and modified SLS network are saved to the log directory.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Initialize the global viper
+		v := viper.GetViper()
+		v.BindPFlags(cmd.Flags())
+
+		name := v.GetString("name")
+
+		modifySLSSubnet(v, func(networkName string, slsSubnet *sls_common.IPV4Subnet) (bool, error) {
+			ipReservation, err := ipam.ReleaseIP(slsSubnet, name)
+			if err != nil {
+				return false, fmt.Errorf("unable to release IP for (%s) in network (%s): %w", name, networkName, err)
+			}
+
+			log.Printf("Released IP %s of %s in subnet %s in network %s\n", ipReservation.IPAddress, name, slsSubnet.Name, networkName)
+			return true, nil
+		})
+	},
+}
+
+// modifySLSSubnet retrieves the SLS network and subnet selected by the network and subnet flags, and applies the given
+// modification to the subnet. If the subnet was modified, then the network is updated in SLS unless this is a dry run.
+// Both the existing and modified network are saved to the log directory.
+func modifySLSSubnet(v *viper.Viper, modify func(networkName string, slsSubnet *sls_common.IPV4Subnet) (bool, error)) {
+	// Setup Context
+	ctx := setupContext()
+
+	// Retrieve API token
+	token := getAPIToken()
+
+	// Create directory to persist data from this run like logs and backups!
+	logDirectory, logFile := setupLogDirectory(v.GetString("log-base-dir"), os.Stdout)
+	defer logFile.Close()
+
+	// Determine if this is a dryrun or not
+	dryRun := v.GetBool("dry-run")
+	if dryRun {
+		log.Println("Dryrun is enabled! No changes to the system will performed.")
+	}
+
+	// Setup SLS client
+	slsURL := v.GetString("sls-url")
+	slsClient := sls_client.NewSLSClient(slsURL, newHTTPClient().StandardClient(), "").WithAPIToken(token)
+
+	log.Printf("Retrieving current SLS state from %s\n", slsURL)
+	currentSLSState, err := slsClient.GetDumpState(ctx)
+	if err != nil {
+		log.Fatal("Error: ", err)
+	}
+
+	networkName := v.GetString("network")
+	slsNetwork, ok := currentSLSState.Networks[networkName]
+	if !ok {
+		log.Fatalf("Error: network (%s) does not exist in SLS", networkName)
+	}
+
+	// Save existing SLS network
+	writeJSONFile(path.Join(logDirectory, fmt.Sprintf("existing_sls_network_%s.json", networkName)), slsNetwork)
+
+	var networkExtraProperties sls_common.NetworkExtraProperties
+	if err := sls.DecodeNetworkExtraProperties(slsNetwork.ExtraPropertiesRaw, &networkExtraProperties); err != nil {
+		log.Fatal("Error: ", err)
+	}
+
+	subnetName := v.GetString("subnet")
+	subnetIndex := -1
+	for i, slsSubnet := range networkExtraProperties.Subnets {
+		if slsSubnet.Name == subnetName {
+			subnetIndex = i
+			break
+		}
+	}
+	if subnetIndex == -1 {
+		log.Fatalf("Error: subnet (%s) does not exist in network (%s)", subnetName, networkName)
+	}
+
+	modified, err := modify(networkName, &networkExtraProperties.Subnets[subnetIndex])
+	if err != nil {
+		log.Fatal("Error: ", err)
+	}
+
+	if !modified {
+		log.Printf("No SLS network changes required")
+		return
+	}
+
+	slsNetwork.ExtraPropertiesRaw = networkExtraProperties
+
+	// Write out modified SLS network
+	writeJSONFile(path.Join(logDirectory, fmt.Sprintf("modified_sls_network_%s.json", networkName)), slsNetwork)
+
+	if dryRun {
+		log.Printf("Dry run enabled not modifying SLS network %s\n", networkName)
+		return
+	}
+
+	log.Printf("Updating SLS network %s\n", networkName)
+	if err := slsClient.PutNetwork(ctx, slsNetwork); err != nil {
+		log.Fatal("Error: ", err)
+	}
+}
+
+func init() {
+	ipamCmd.AddCommand(ipamReserveCmd)
+	ipamCmd.AddCommand(ipamReleaseCmd)
+
+	for _, command := range []*cobra.Command{ipamReserveCmd, ipamReleaseCmd} {
+		command.Flags().SortFlags = false
+
+		command.Flags().Bool("dry-run", false, "Perform a dry run and not make changes to the system")
+		command.Flags().String("network", "", "Name of the SLS network, such as CAN")
+		command.Flags().String("subnet", "bootstrap_dhcp", "Name of the subnet within the SLS network")
+		command.Flags().String("name", "", "Name of the IP reservation")
+		if command == ipamReserveCmd {
+			command.Flags().String("comment", "", "Comment of the IP reservation, such as the xname of the device using the IP")
+		}
+		command.Flags().String("log-base-dir", ".", "Directory to contain the log folder generated from each run")
+
+		command.Flags().String("sls-url", "https://api-gw-service-nmn.local/apis/sls", "Advanced option: URL to System Layout Service (SLS)")
+
+		command.MarkFlagRequired("network")
+		command.MarkFlagRequired("name")
+	}
+}
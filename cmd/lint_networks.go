@@ -0,0 +1,108 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/sls"
+	sls_client "github.com/Cray-HPE/hms-sls/pkg/sls-client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// lintNetworksCmd represents the lint-networks command
+var lintNetworksCmd = &cobra.Command{
+	Use:   "lint-networks",
+	Args:  cobra.NoArgs,
+	Short: "Check the networks stored within SLS for problems.",
+	Long: `Check the networks stored within SLS for problems.
+
+The following problems are reported as errors:
+- Subnets outside of their network CIDR
+- Overlapping subnets within a network
+- IP reservations outside of their subnet CIDR
+- Duplicate IP reservation IPs within a network, or names within a subnet
+- IP reservations using the gateway IP
+- Gateways outside of their subnet CIDR, or within the DHCP range
+- DHCP ranges outside of their subnet CIDR, or with DHCPStart after DHCPEnd
+
+The following problems are reported as warnings:
+- IP reservations within the DHCP range
+- Subnets with only one of DHCPStart and DHCPEnd set
+
+The subnet layout created by CSI is not reported. Subnets with the mask of their
+network, such as bootstrap_dhcp and network_hardware after the supernet hack, may
+overlap the other subnets, and subnets may use the gateway of their network, such
+as uai_macvlan.
+
+This check also runs as a preflight check of the update command. This command
+exits with a non-zero exit code if any errors are found.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Initialize the global viper
+		v := viper.GetViper()
+		v.BindPFlags(cmd.Flags())
+
+		ctx := setupContext()
+		token := getAPIToken()
+
+		slsURL := v.GetString("sls-url")
+		slsClient := sls_client.NewSLSClient(slsURL, newHTTPClient().StandardClient(), "").WithAPIToken(token)
+
+		log.Printf("Retrieving current SLS state from %s\n", slsURL)
+		currentSLSState, err := slsClient.GetDumpState(ctx)
+		if err != nil {
+			log.Fatal("Error: ", err)
+		}
+
+		lintFindings := sls.LintNetworks(currentSLSState.Networks)
+		logLintFindings(lintFindings)
+
+		if lintFindings.HasErrors() {
+			os.Exit(1)
+		}
+	},
+}
+
+// logLintFindings will log each of the network lint findings.
+func logLintFindings(lintFindings sls.LintFindings) {
+	if len(lintFindings) == 0 {
+		log.Println("No problems found with the SLS networks")
+		return
+	}
+
+	log.Printf("Found %d problem(s) with the SLS networks\n", len(lintFindings))
+	for _, finding := range lintFindings {
+		log.Printf("  %s\n", finding)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(lintNetworksCmd)
+
+	lintNetworksCmd.Flags().SortFlags = false
+
+	lintNetworksCmd.Flags().String("sls-url", "https://api-gw-service-nmn.local/apis/sls", "Advanced option: URL to System Layout Service (SLS)")
+}
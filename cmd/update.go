@@ -24,29 +24,27 @@ package cmd
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"path"
-	"reflect"
+	"sort"
 	"strings"
 	"syscall"
-	"time"
 
 	"github.com/Cray-HPE/hardware-topology-assistant/internal/engine"
+	"github.com/Cray-HPE/hardware-topology-assistant/internal/report"
 	"github.com/Cray-HPE/hardware-topology-assistant/pkg/bss"
 	"github.com/Cray-HPE/hardware-topology-assistant/pkg/ccj"
 	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/diff"
 	"github.com/Cray-HPE/hardware-topology-assistant/pkg/sls"
 	"github.com/Cray-HPE/hms-bss/pkg/bssTypes"
 	sls_client "github.com/Cray-HPE/hms-sls/pkg/sls-client"
-	"github.com/hashicorp/go-retryablehttp"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -88,7 +86,93 @@ Current Limitations:
 If new application nodes are being added to the system, then this tool will
 automatically generate the application-node-metadata.yaml configuration for the
 user to fill any ~~FIXME~~ values for any new application nodes being added to
-the system regarding their desired HSM SubRole, and alias.
+the system regarding their desired HSM SubRole, and alias. If an application node
+metadata file was provided but is missing new application nodes, then an updated
+copy of the file is written out with ~~FIXME~~ entries added for only the missing
+application nodes.
+
+By default new UANs are given an IP in the bootstrap_dhcp subnet of the CAN and
+CHN networks. The networks and subnets application nodes need IPs in can be
+controlled for each HSM SubRole with an application network policy file, which
+replaces the default policy. If the subnet is not given, then bootstrap_dhcp is
+used. For example:
+    UAN:
+      - network: CAN
+      - network: CHN
+    Gateway:
+      - network: CAN
+      - network: CHN
+      - network: CMN
+
+New cabinet subnets are /22 networks by default. The size of new cabinet subnets,
+along with the gateway and DHCP range within them, can be controlled for each
+network with an addressing plan file. For example:
+    HMN_RVR:
+      prefix_length: 24
+      gateway_offset: 1
+      dhcp_start_offset: 10
+      dhcp_end_reserve: 1
+
+A network can be made dual-stack by adding an ipv6_cidr, and optionally an
+ipv6_prefix_length (default 64), to its addressing plan. New cabinet subnets are
+given an IPv6 prefix carved out of the IPv6 CIDR, which is recorded in the network
+information of the cabinet and in the comment of the subnet. The HMN IP6addr of
+new management switches is given the IPv6 address at the same offset as their
+IPv4 address. Other IP reservations remain IPv4 only, as SLS has no place to
+record their IPv6 addresses.
+
+Cabinet routes are written for the VLAN interfaces of each management NCN. The
+parent device of each network is taken from the existing IPAM metadata of the NCN
+in BSS. The VLAN interface naming is inferred from the existing NMN and HMN route
+files of the NCN, and is like bond0.nmn0 when there are none. If the existing
+route files do not follow a naming that can be inferred, the update stops. The
+naming can be overridden for each NCN with an NCN interface overrides file. The VLAN
+interface format is formatted with the parent device and network name. For
+example:
+    x3000c0s1b0n0:
+      parent_device: bond1
+      vlan_interface_format: "%s.%s0"
+
+The host records of the BSS Global boot parameters include well-known host
+records built from IP reservations in SLS, such as the kubeapi-vip, rgw-vip, and
+API gateway. These can be replaced with a host records file. For example:
+    - network: NMN
+      subnet: bootstrap_dhcp
+      reservation: kubeapi-vip
+      aliases: [kubeapi-vip, kubeapi-vip.nmn]
+The existing pit host record is kept as is.
+
+If the VLANs of new cabinets have already been assigned on the management
+switches, then they can be provided with a cabinet network overrides file
+instead of being automatically allocated. The CIDR is optional. For example:
+    x3001:
+      HMN_RVR:
+        vlan: 1600
+        cidr: 10.107.8.0/22
+      NMN_RVR:
+        vlan: 1800
+
+A unified diff is shown for the subnets of each modified SLS network, the host
+records of the BSS Global boot parameters, and the write_files of each modified
+management NCN. The diffs are colorized on a terminal, and saved to the log
+directory.
+
+With --output json or yaml, a versioned report of the hardware added or removed,
+the subnets, IP reservations and static IP range expansions added to SLS
+networks, and the changed BSS entries is written to stdout. The report is also
+written when hardware was removed or has differing values, with the status
+"refused" and the offending hardware, and when the run fails after the changes
+were determined, with the status "failed". The applied field lists whether SLS
+and which BSS entries were already written. The schema_version field only
+changes when a field is removed or changes meaning.
+
+Exit codes:
+  0  The hardware topology was updated, or is already up to date.
+  1  The update failed for any other reason.
+  2  Refused to continue, as hardware was removed or has differing values.
+  3  A network or subnet has no space left for new subnets, VLANs or IP addresses.
+  4  The CCJ, such as duplicate compute NIDs, or one of the configuration files,
+     such as an unusable cabinet network override, is invalid.
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Initialize the global viper
@@ -99,42 +183,35 @@ the system regarding their desired HSM SubRole, and alias.
 		ctx := setupContext()
 
 		// Retrieve API token
-		token := os.Getenv("TOKEN")
-		if token == "" {
-			log.Fatal("Error environment variable TOKEN was not set")
-		}
+		token := getAPIToken()
+
+		// When a report is written to stdout, then the logs are written to stderr instead to keep stdout parseable
+		var outputFormat report.Format
+		logConsole := os.Stdout
+		if output := v.GetString("output"); output != "" {
+			var err error
+			outputFormat, err = report.ParseFormat(output)
+			if err != nil {
+				log.Fatal("Error: ", err)
+			}
 
-		// Create directory to persist data from this run like logs and backups!
-		logBaseDirectory := v.GetString("log-base-dir")
-		timestamp := strings.Replace(time.Now().UTC().Format(time.RFC3339), ":", "-", -1)
-		logDirectory := path.Join(logBaseDirectory, fmt.Sprintf("hardware-topology-assistant_%s", timestamp))
-		log.Printf("Log directory is at %s", logDirectory)
-		if err := os.MkdirAll(logDirectory, 0700); err != nil {
-			log.Fatalf("Failed to create log directory at %s due to: %s", logDirectory, err)
+			logConsole = os.Stderr
 		}
 
-		// Setup the log package to write to both stdout and a log file
-		logFilePath := path.Join(logDirectory, "hardware-topology-assistant.log")
-		logFile, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0600)
-		if err != nil {
-			log.Fatal(err)
-		}
+		// Create directory to persist data from this run like logs and backups!
+		logDirectory, logFile := setupLogDirectory(v.GetString("log-base-dir"), logConsole)
 		defer logFile.Close()
 
-		logWriter := io.MultiWriter(os.Stdout, logFile)
-		log.SetOutput(logWriter)
-
 		// Determine if this is a dryrun or not
 		dryRun := v.GetBool("dry-run")
 		if dryRun {
 			log.Println("Dryrun is enabled! No changes to the system will performed.")
 		}
 
+		changeReport := report.New(dryRun)
+
 		// Setup HTTP client
-		httpClient := retryablehttp.NewClient()
-		httpClient.HTTPClient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
+		httpClient := newHTTPClient()
 
 		// Setup SLS client
 		slsURL := v.GetString("sls-url")
@@ -192,11 +269,12 @@ the system regarding their desired HSM SubRole, and alias.
 		// TODO the prefixes list is not being used, as we are assuming all unknown nodes are application
 		applicationNodeMetadataFile := v.GetString("application-node-metadata")
 		var applicationNodeMetadata configs.ApplicationNodeMetadataMap
+		var applicationNodeMetadataRaw []byte
 		if applicationNodeMetadataFile == "" {
 			log.Printf("No application node metadata file provided.\n")
 		} else {
 			log.Printf("Using application node metadata file at %s\n", applicationNodeMetadataFile)
-			applicationNodeMetadataRaw, err := ioutil.ReadFile(applicationNodeMetadataFile)
+			applicationNodeMetadataRaw, err = ioutil.ReadFile(applicationNodeMetadataFile)
 			if err != nil {
 				log.Fatal("Error: ", err)
 			}
@@ -206,6 +284,94 @@ the system regarding their desired HSM SubRole, and alias.
 			}
 		}
 
+		// Read in the NID assignment configuration
+		nidAssignmentFile := v.GetString("nid-assignment")
+		var nidAssignment configs.NIDAssignment
+		if nidAssignmentFile == "" {
+			log.Printf("No NID assignment file provided, compute node NIDs will be parsed from their CANU common names.\n")
+		} else {
+			log.Printf("Using NID assignment file at %s\n", nidAssignmentFile)
+			nidAssignmentRaw, err := ioutil.ReadFile(nidAssignmentFile)
+			if err != nil {
+				log.Fatal("Error: ", err)
+			}
+
+			if err := yaml.Unmarshal(nidAssignmentRaw, &nidAssignment); err != nil {
+				log.Fatal("Error: ", err)
+			}
+		}
+		if aliasFormat := v.GetString("compute-alias-format"); aliasFormat != "" {
+			nidAssignment.AliasFormat = aliasFormat
+		}
+
+		if err := nidAssignment.Validate(); err != nil {
+			log.Fatal("Error: ", err)
+		}
+
+		// Read in the networks application nodes need IP reservations in
+		applicationNetworkPolicy := readApplicationNetworkPolicy(v.GetString("application-network-policy"))
+
+		// Read in the network interface naming of NCNs that do not use their existing or the default naming
+		ncnInterfaceOverridesFile := v.GetString("ncn-interface-overrides")
+		var ncnInterfaceOverrides configs.NCNInterfaceNamingOverrides
+		if ncnInterfaceOverridesFile != "" {
+			log.Printf("Using NCN interface overrides file at %s\n", ncnInterfaceOverridesFile)
+			ncnInterfaceOverridesRaw, err := ioutil.ReadFile(ncnInterfaceOverridesFile)
+			if err != nil {
+				log.Fatal("Error: ", err)
+			}
+
+			if err := yaml.Unmarshal(ncnInterfaceOverridesRaw, &ncnInterfaceOverrides); err != nil {
+				log.Fatal("Error: ", err)
+			}
+		}
+
+		if err := ncnInterfaceOverrides.Validate(); err != nil {
+			log.Fatal("Error: ", err)
+		}
+
+		// Read in the well-known host records, such as VIPs
+		hostRecordsFile := v.GetString("host-records")
+		wellKnownHostRecords := configs.DefaultWellKnownHostRecords
+		if hostRecordsFile != "" {
+			log.Printf("Using host records file at %s\n", hostRecordsFile)
+			hostRecordsRaw, err := ioutil.ReadFile(hostRecordsFile)
+			if err != nil {
+				log.Fatal("Error: ", err)
+			}
+
+			wellKnownHostRecords = nil
+			if err := yaml.Unmarshal(hostRecordsRaw, &wellKnownHostRecords); err != nil {
+				log.Fatal("Error: ", err)
+			}
+		}
+
+		if err := wellKnownHostRecords.Validate(); err != nil {
+			log.Fatal("Error: ", err)
+		}
+
+		// Read in the user specified VLANs and CIDRs for new cabinets
+		cabinetNetworkOverridesFile := v.GetString("cabinet-network-overrides")
+		var cabinetNetworkOverrides configs.CabinetNetworkOverrides
+		if cabinetNetworkOverridesFile != "" {
+			log.Printf("Using cabinet network overrides file at %s\n", cabinetNetworkOverridesFile)
+			cabinetNetworkOverridesRaw, err := ioutil.ReadFile(cabinetNetworkOverridesFile)
+			if err != nil {
+				log.Fatal("Error: ", err)
+			}
+
+			if err := yaml.Unmarshal(cabinetNetworkOverridesRaw, &cabinetNetworkOverrides); err != nil {
+				log.Fatal("Error: ", err)
+			}
+
+			if err := cabinetNetworkOverrides.Validate(); err != nil {
+				log.Fatal("Error: ", err)
+			}
+		}
+
+		// Read in the addressing plan for new cabinet subnets
+		addressingPlan := readAddressingPlan(v.GetString("addressing-plan"))
+
 		//
 		// Retrieve current state from the system
 		//
@@ -233,6 +399,17 @@ the system regarding their desired HSM SubRole, and alias.
 			log.Fatal("Refusing to continue as the current SLS state does not contain networking information")
 		}
 
+		// Preflight: Verify the current SLS networks are consistent before making changes to them
+		if v.GetBool("skip-network-lint") {
+			log.Println("Warning: Skipping the network lint preflight check")
+		} else {
+			lintFindings := sls.LintNetworks(currentSLSState.Networks)
+			logLintFindings(lintFindings)
+			if lintFindings.HasErrors() {
+				log.Fatal("Refusing to continue as errors were found with the current SLS networks. Run the lint-networks command for more information")
+			}
+		}
+
 		// Build up the application node metadata for the current state of the system
 		currentApplicationNodeMetadata, err := sls.BuildApplicationNodeMetadata(currentSLSState.Hardware)
 		if err != nil {
@@ -249,12 +426,40 @@ the system regarding their desired HSM SubRole, and alias.
 			log.Fatal("The current SLS state contains application nodes that share the same alias. Please reconcile before continuing.")
 		}
 
+		var updatedApplicationNodeMetadataRaw []byte
 		if applicationNodeMetadataFile == "" {
 			// Build up the application metadata config for the expected state of the system if no file was provided.
-			applicationNodeMetadata, err = ccj.BuildApplicationNodeMetadata(paddle, currentApplicationNodeMetadata)
+			applicationNodeMetadata, err = ccj.BuildApplicationNodeMetadata(paddle, currentApplicationNodeMetadata, nidAssignment)
+			if err != nil {
+				log.Fatal("Error: ", err)
+			}
+		} else {
+			// Check to see if the provided application node metadata is missing any application nodes present in the CCJ,
+			// or contains application nodes that are no longer present in the CCJ.
+			missingApplicationNodeMetadata, staleApplicationNodes, err := ccj.DiffApplicationNodeMetadata(paddle, applicationNodeMetadata, nidAssignment)
 			if err != nil {
 				log.Fatal("Error: ", err)
 			}
+
+			if len(staleApplicationNodes) != 0 {
+				log.Printf("The application node metadata file %s contains entries for nodes that are not application nodes in the CCJ:\n", applicationNodeMetadataFile)
+				for _, xname := range staleApplicationNodes {
+					log.Printf("  %s\n", xname)
+				}
+			}
+
+			if len(missingApplicationNodeMetadata) != 0 {
+				// Merge the missing application nodes into a copy of the provided file, so existing entries and comments are kept.
+				updatedApplicationNodeMetadataRaw, err = configs.MergeApplicationNodeMetadataYAML(applicationNodeMetadataRaw, missingApplicationNodeMetadata)
+				if err != nil {
+					log.Fatal("Error: ", err)
+				}
+
+				for xname, metadata := range missingApplicationNodeMetadata {
+					log.Printf("Application node %s (%s) is missing from the application node metadata file\n", xname, metadata.CANUCommonName)
+					applicationNodeMetadata[xname] = metadata
+				}
+			}
 		}
 
 		// At this point we can detect if any application nodes are missing required data
@@ -304,6 +509,23 @@ the system regarding their desired HSM SubRole, and alias.
 				if err != nil {
 					log.Fatal("Error: ", err)
 				}
+			} else if updatedApplicationNodeMetadataRaw != nil {
+				// The provided application node metadata file is missing new application nodes, so write out an updated
+				// copy of it next to the original with the missing entries added.
+				extension := path.Ext(applicationNodeMetadataFile)
+				updatedApplicationNodeMetadataFile := strings.TrimSuffix(applicationNodeMetadataFile, extension) + "_updated" + extension
+
+				// Check to see if the file exists
+				if _, err := os.Stat(updatedApplicationNodeMetadataFile); err == nil {
+					log.Fatalf("Error %s already exists. Refusing to overwrite!\n", updatedApplicationNodeMetadataFile)
+				}
+
+				log.Printf("Updated application node metadata file is now available at: %s\n", updatedApplicationNodeMetadataFile)
+				log.Printf("Add --application-node-metadata=%s to the command line arguments and try again.\n", updatedApplicationNodeMetadataFile)
+				err = ioutil.WriteFile(updatedApplicationNodeMetadataFile, updatedApplicationNodeMetadataRaw, 0600)
+				if err != nil {
+					log.Fatal("Error: ", err)
+				}
 			}
 
 			os.Exit(1)
@@ -328,7 +550,7 @@ the system regarding their desired HSM SubRole, and alias.
 		}
 
 		log.Println("Retrieving Global boot parameters from BSS")
-		bssGlobalBootParameters, err := bssClient.GetBSSBootparametersByName("Global")
+		bssGlobalBootParameters, err := bssClient.GetBSSBootparametersByName(ctx, "Global")
 		if err != nil {
 			log.Fatal("Error: ", err)
 		}
@@ -344,15 +566,19 @@ the system regarding their desired HSM SubRole, and alias.
 			log.Fatal(err)
 		}
 
-		managementNCNBootParams := map[string]*bssTypes.BootParams{}
+		var managementNCNXnames []string
 		for _, managementNCN := range managementNCNs {
-			log.Printf("Retrieving boot parameters for %s from BSS\n", managementNCN.Xname)
-			bootParams, err := bssClient.GetBSSBootparametersByName(managementNCN.Xname)
-			if err != nil {
-				log.Fatal("Error: ", err)
-			}
+			managementNCNXnames = append(managementNCNXnames, managementNCN.Xname)
+		}
+
+		log.Printf("Retrieving boot parameters for %d management NCNs from BSS\n", len(managementNCNXnames))
+		managementNCNBootParams, err := bssClient.GetBSSBootparametersByNames(ctx, managementNCNXnames)
+		if err != nil {
+			log.Fatal("Error: ", err)
+		}
 
-			managementNCNBootParams[managementNCN.Xname] = bootParams
+		for _, managementNCN := range managementNCNs {
+			bootParams := managementNCNBootParams[managementNCN.Xname]
 
 			// Save Management NCN boot parameters
 			existingBSSBootParametersFile := path.Join(logDirectory, fmt.Sprintf("existing_bss_bootparameters_%s.json", managementNCN.Xname))
@@ -373,6 +599,10 @@ the system regarding their desired HSM SubRole, and alias.
 			Input: engine.EngineInput{
 				Paddle:                                 paddle,
 				ApplicationNodeMetadata:                applicationNodeMetadata,
+				NIDAssignment:                          nidAssignment,
+				ApplicationNetworkPolicy:               applicationNetworkPolicy,
+				AddressingPlan:                         addressingPlan,
+				CabinetNetworkOverrides:                cabinetNetworkOverrides,
 				CurrentSLSState:                        currentSLSState,
 				HardwareToIgnore:                       v.GetStringSlice("hardware-ignore-list"),
 				IgnoreRemovedHardware:                  v.GetBool("ignore-removed-hardware"),
@@ -380,9 +610,33 @@ the system regarding their desired HSM SubRole, and alias.
 			},
 		}
 
+		// From here on failures are written to the report, along with the changes that were already applied
+		fatal := func(err error) {
+			fatalWithReport(changeReport, outputFormat, err)
+		}
+
 		topologyChanges, err := topologyEngine.DetermineChanges()
 		if err != nil {
-			log.Fatal("Error: ", err)
+			fatal(err)
+		}
+		changeReport.AddTopologyChanges(topologyChanges)
+
+		// Show the changes to the subnets of each modified network
+		var modifiedNetworkNames []string
+		for name := range topologyChanges.ModifiedNetworks {
+			modifiedNetworkNames = append(modifiedNetworkNames, name)
+		}
+		sort.Strings(modifiedNetworkNames)
+
+		for _, name := range modifiedNetworkNames {
+			subnetDiffs, err := sls.SubnetDiffs(currentSLSState.Networks[name], topologyChanges.ModifiedNetworks[name])
+			if err != nil {
+				fatal(err)
+			}
+
+			if err := writeDiff(logConsole, logDirectory, fmt.Sprintf("sls_network_%s.diff", name), subnetDiffs); err != nil {
+				fatal(err)
+			}
 		}
 
 		// Merge Topology Changes into the current SLS state
@@ -397,11 +651,11 @@ the system regarding their desired HSM SubRole, and alias.
 		topologyChangesFile := path.Join(logDirectory, "topology_changes.json")
 		topologyChangesRaw, err := json.MarshalIndent(topologyChanges, "", "  ")
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 
 		if err := ioutil.WriteFile(topologyChangesFile, topologyChangesRaw, 0700); err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 
 		//
@@ -418,25 +672,55 @@ the system regarding their desired HSM SubRole, and alias.
 		// Determine changes requires to downstream services from SLS. Like HSM and BSS
 		//
 
-		// TODO For right now lets just always push the host records, unless the reflect.DeepEqual
-		// says they are equal.
-		// Because the logic to compare the expected BSS host records with the current ones
-		// is kind of hard. If anything is different just recalculate it.
-		// This should be harmless just the order of records will shift around.
+		// Customer networks, such as the CAN and CHN, and which of them is the system default route
+		bicanMode, extraNets, err := bss.CustomerIPAMNetworks(sls.Networks(currentSLSState))
+		if err != nil {
+			fatal(err)
+		}
+		log.Printf("BICAN mode: the system default route is on the %s network\n", bicanMode)
 
-		// Recalculate the systems host recorded
+		// Recalculate the systems host records. Only the host records derived from SLS are added or updated, and any
+		// other host records such as site added ones are kept.
 		modifiedGlobalBootParameters := false
-		expectedGlobalHostRecords := bss.GetBSSGlobalHostRecords(managementNCNs, sls.Networks(currentSLSState))
-
 		var currentGlobalHostRecords bss.HostRecords
 		if err := mapstructure.Decode(bssGlobalBootParameters.CloudInit.MetaData["host_records"], &currentGlobalHostRecords); err != nil {
-			log.Fatal("Error: ", err)
+			fatal(err)
 		}
 
-		if !reflect.DeepEqual(currentGlobalHostRecords, expectedGlobalHostRecords) {
-			log.Println("Host records in BSS Global boot parameters are out of date")
-			bssGlobalBootParameters.CloudInit.MetaData["host_records"] = expectedGlobalHostRecords
+		// The pit host record is not derived from SLS, so keep the current one
+		var pitHostRecord *bss.HostRecord
+		if hostRecord, found := bss.FindHostRecord(currentGlobalHostRecords, "pit"); found {
+			pitHostRecord = &hostRecord
+		} else {
+			log.Println("No pit host record found in BSS Global boot parameters")
+		}
+
+		expectedGlobalHostRecords, err := bss.GetBSSGlobalHostRecords(managementNCNs, sls.Networks(currentSLSState), wellKnownHostRecords, pitHostRecord)
+		if err != nil {
+			fatal(err)
+		}
+
+		reconciledGlobalHostRecords, hostRecordsReport := bss.ReconcileHostRecords(currentGlobalHostRecords, expectedGlobalHostRecords)
+		changeReport.AddHostRecordChanges("Global", hostRecordsReport)
+		if hostRecordsReport.HasChanges() {
+			log.Printf("Host records in BSS Global boot parameters are out of date (added %d, removed %d, changed %d, kept %d unknown)\n",
+				len(hostRecordsReport.Added), len(hostRecordsReport.Removed), len(hostRecordsReport.Changed), hostRecordsReport.Unknown)
+			for _, line := range hostRecordsReport.Summary() {
+				log.Printf("  %s\n", line)
+			}
+
+			hostRecordsDiff, err := diff.Unified("Global/host_records", currentGlobalHostRecords.Text(), reconciledGlobalHostRecords.Text())
+			if err != nil {
+				fatal(err)
+			}
+			if err := writeDiff(logConsole, logDirectory, "bss_bootparameters_Global.diff", hostRecordsDiff); err != nil {
+				fatal(err)
+			}
+
+			bssGlobalBootParameters.CloudInit.MetaData["host_records"] = reconciledGlobalHostRecords
 			modifiedGlobalBootParameters = true
+		} else {
+			log.Printf("Host records in BSS Global boot parameters are up to date (kept %d unknown)\n", hostRecordsReport.Unknown)
 		}
 
 		// Recalculate cabinet routes
@@ -444,40 +728,61 @@ the system regarding their desired HSM SubRole, and alias.
 		modifiedManagementNCNBootParams := map[string]bool{}
 
 		for _, managementNCN := range managementNCNs {
+			// Determine the network interface naming of the NCN from its existing IPAM metadata and route files or override
+			ncnBootParams := managementNCNBootParams[managementNCN.Xname]
+			ncnInterfaces, err := bss.DetermineNCNInterfaces(ncnBootParams.CloudInit.MetaData["ipam"], ncnBootParams.CloudInit.UserData["write_files"], ncnInterfaceOverrides[managementNCN.Xname])
+			if err != nil {
+				fatal(fmt.Errorf("unable to determine network interfaces for %s: %w", managementNCN.Xname, err))
+			}
 
-			// The following was stolen from CSI
-			extraNets := []string{}
-			var foundCAN = false
-			var foundCHN = false
-
-			for _, net := range sls.Networks(currentSLSState) {
-				if strings.ToLower(net.Name) == "can" {
-					extraNets = append(extraNets, "can")
-					foundCAN = true
-				}
-				if strings.ToLower(net.Name) == "chn" {
-					foundCHN = true
-				}
+			// IPAM
+			ipamNetworks, err := bss.GetIPAMForNCN(managementNCN, sls.Networks(currentSLSState), ncnInterfaces, extraNets...)
+			if err != nil {
+				fatal(err)
 			}
-			if !foundCAN && !foundCHN {
-				log.Fatal("Error no CAN or CHN network defined in SLS networks")
+			bss.ApplyBICANMode(ipamNetworks, bicanMode)
+			expectedWriteFiles, err := bss.GetWriteFiles(sls.Networks(currentSLSState), ipamNetworks, ncnInterfaces)
+			if err != nil {
+				fatal(err)
 			}
 
-			// IPAM
-			ipamNetworks := bss.GetIPAMForNCN(managementNCN, sls.Networks(currentSLSState), extraNets...)
-			expectedWriteFiles := bss.GetWriteFiles(sls.Networks(currentSLSState), ipamNetworks)
+			// Only the cabinet route files are replaced or removed, any other write_files entries are left as is.
+			userData := managementNCNBootParams[managementNCN.Xname].CloudInit.UserData
+			mergedWriteFiles, writeFileChanges, err := bss.MergeWriteFiles(userData["write_files"], expectedWriteFiles, ncnInterfaces)
+			if err != nil {
+				fatal(fmt.Errorf("unable to merge write_files for %s: %w", managementNCN.Xname, err))
+			}
+			changeReport.AddWriteFileChanges(managementNCN.Xname, writeFileChanges)
 
-			var currentWriteFiles []bss.WriteFile
-			if err := mapstructure.Decode(managementNCNBootParams[managementNCN.Xname].CloudInit.UserData["write_files"], &currentWriteFiles); err != nil {
-				panic(err)
+			// Route files of the same networks that were not built by this tool are kept, but may conflict
+			unmanagedRouteFiles, err := bss.UnmanagedRouteFiles(userData["write_files"], ncnInterfaces)
+			if err != nil {
+				fatal(fmt.Errorf("unable to merge write_files for %s: %w", managementNCN.Xname, err))
+			}
+			changeReport.AddUnmanagedRouteFiles(managementNCN.Xname, unmanagedRouteFiles)
+			for _, unmanagedRouteFile := range unmanagedRouteFiles {
+				log.Printf("Warning: Keeping route file %s for %s in BSS boot parameters, as it was not built by this tool\n", unmanagedRouteFile, managementNCN.Xname)
 			}
 
-			// TODO For right now lets just always push the writefiles, unless the reflect.DeepEqual
-			// says they are equal.
-			// This should be harmless, the cabinet routes may be in a different order. This is due to cabinet routes do not overlap with each other.
-			if !reflect.DeepEqual(expectedWriteFiles, currentWriteFiles) {
-				log.Printf("Cabinet routes for %s in BSS Global boot parameters are out of date\n", managementNCN.Xname)
-				managementNCNBootParams[managementNCN.Xname].CloudInit.UserData["write_files"] = expectedWriteFiles
+			if len(writeFileChanges) != 0 {
+				log.Printf("Cabinet routes for %s in BSS boot parameters are out of date\n", managementNCN.Xname)
+				for _, change := range writeFileChanges {
+					log.Printf("  %s (%s)\n", change.Path, change.Action)
+				}
+
+				var writeFilesDiff strings.Builder
+				for _, change := range writeFileChanges {
+					writeFileDiff, err := diff.Unified(managementNCN.Xname+change.Path, change.Previous.Text(), change.Current.Text())
+					if err != nil {
+						fatal(err)
+					}
+					writeFilesDiff.WriteString(writeFileDiff)
+				}
+				if err := writeDiff(logConsole, logDirectory, fmt.Sprintf("bss_bootparameters_%s.diff", managementNCN.Xname), writeFilesDiff.String()); err != nil {
+					fatal(err)
+				}
+
+				userData["write_files"] = mergedWriteFiles
 				modifiedManagementNCNBootParams[managementNCN.Xname] = true
 			}
 
@@ -501,11 +806,11 @@ the system regarding their desired HSM SubRole, and alias.
 		modifiedSLSStateFile := path.Join(logDirectory, "modified_sls_state.json")
 		modifiedSLSStateRaw, err := json.MarshalIndent(currentSLSState, "", "  ")
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 
 		if err := ioutil.WriteFile(modifiedSLSStateFile, modifiedSLSStateRaw, 0600); err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 
 		// Identify modified BSS boot parameters
@@ -528,11 +833,11 @@ the system regarding their desired HSM SubRole, and alias.
 			modifiedBSSBootParametersFile := path.Join(logDirectory, fmt.Sprintf("modified_bss_bootparameters_%s.json", name))
 			modifiedBSSBootParametersRaw, err := json.MarshalIndent(bootParameters, "", "  ")
 			if err != nil {
-				log.Fatal(err)
+				fatal(err)
 			}
 
 			if err := ioutil.WriteFile(modifiedBSSBootParametersFile, modifiedBSSBootParametersRaw, 0600); err != nil {
-				log.Fatal(err)
+				fatal(err)
 			}
 		}
 
@@ -551,7 +856,7 @@ the system regarding their desired HSM SubRole, and alias.
 				} else {
 					err := slsClient.PutHardware(ctx, hardware)
 					if err != nil {
-						log.Fatal("Error: ", err)
+						fatal(err)
 					}
 				}
 			}
@@ -568,11 +873,14 @@ the system regarding their desired HSM SubRole, and alias.
 				} else {
 					err := slsClient.PutNetwork(ctx, modifiedNetwork)
 					if err != nil {
-						log.Fatal("Error: ", err)
+						fatal(err)
 					}
 				}
 			}
 		}
+		if !dryRun && (len(topologyChanges.HardwareAdded) != 0 || len(topologyChanges.ModifiedNetworks) != 0) {
+			changeReport.SetSLSApplied()
+		}
 
 		// Update BSS Global Bootparams
 		if !modifiedGlobalBootParameters {
@@ -583,10 +891,11 @@ the system regarding their desired HSM SubRole, and alias.
 			if dryRun {
 				log.Println("  Dry run enabled not modifying BSS")
 			} else {
-				_, err := bssClient.UploadEntryToBSS(*bssGlobalBootParameters, http.MethodPut)
+				_, err := bssClient.UploadEntryToBSS(ctx, *bssGlobalBootParameters, http.MethodPut)
 				if err != nil {
-					log.Fatal("Error: ", err)
+					fatal(err)
 				}
+				changeReport.AddBSSApplied("Global")
 			}
 		}
 
@@ -603,11 +912,18 @@ the system regarding their desired HSM SubRole, and alias.
 			if dryRun {
 				log.Println("  Dry run enabled not modifying BSS")
 			} else {
-				_, err := bssClient.UploadEntryToBSS(*managementNCNBootParams[xname], http.MethodPut)
+				_, err := bssClient.UploadEntryToBSS(ctx, *managementNCNBootParams[xname], http.MethodPut)
 				if err != nil {
-					log.Fatal("Error: ", err)
+					fatal(err)
 				}
+				changeReport.AddBSSApplied(xname)
+			}
+		}
 
+		// Write out the machine readable report of the changes
+		if outputFormat != "" {
+			if err := changeReport.Write(os.Stdout, outputFormat); err != nil {
+				log.Fatal("Error: ", err)
 			}
 		}
 	},
@@ -631,10 +947,19 @@ func init() {
 
 	updateCmd.Flags().Bool("dry-run", false, "Perform a dry run and not make changes to the system")
 	updateCmd.Flags().String("application-node-metadata", "", "YAML to control Application node identification during the SLS State generation. Only required if application nodes are being added to the system")
+	updateCmd.Flags().String("application-network-policy", "", "YAML to control which networks and subnets application nodes need IPs in, keyed by HSM SubRole. By default UANs are given IPs in the bootstrap_dhcp subnet of the CAN and CHN")
+	updateCmd.Flags().String("nid-assignment", "", "YAML to control how compute nodes are assigned NIDs. By default the NID is parsed from the CANU common name of the compute node. With the cabinet_offset mode the number in the common name is added to the base NID of the cabinet")
+	updateCmd.Flags().String("compute-alias-format", "", "Format of the alias given to compute nodes formatted with its NID, such as nid%06d. Overrides the alias format from the NID assignment file")
+	updateCmd.Flags().String("addressing-plan", "", "YAML to control the prefix length, gateway offset, and DHCP range of new cabinet subnets for each network. By default new cabinet subnets are /22 networks")
+	updateCmd.Flags().String("cabinet-network-overrides", "", "YAML containing the VLAN, and optionally the CIDR, to use for the subnets of new cabinets instead of automatically allocating them. Keyed by cabinet xname and network name")
+	updateCmd.Flags().String("ncn-interface-overrides", "", "YAML containing the parent device and VLAN interface format of management NCNs, keyed by NCN xname. By default these are determined from the existing IPAM metadata of the NCN in BSS")
+	updateCmd.Flags().String("host-records", "", "YAML containing the well-known host records of the BSS Global boot parameters, such as VIPs, and the SLS IP reservations they are built from. Replaces the default kubeapi-vip, rgw-vip, and API gateway host records")
 	updateCmd.Flags().String("log-base-dir", ".", "Directory to contain the log folder generated from each run")
+	updateCmd.Flags().String("output", "", "Write a versioned report of the changes to stdout in the given format, either json or yaml. Logs are written to stderr instead")
 
 	updateCmd.Flags().Bool("ignore-unknown-canu-hardware-architectures", false, "Advanced option: Ignore CANU hardware architectures that are unknown to this tool.")
 	updateCmd.Flags().Bool("ignore-removed-hardware", false, "Advanced option: Ignore hardware removed from the system, and only add new hardware to the system")
+	updateCmd.Flags().Bool("skip-network-lint", false, "Advanced option: Skip the preflight check of the current SLS networks for problems")
 	updateCmd.Flags().StringSlice("hardware-ignore-list", []string{}, "Advanced option: Hardware to ignore specified as xnames. Multiple xnames can be specified in a comma separated list")
 
 	updateCmd.Flags().String("sls-url", "https://api-gw-service-nmn.local/apis/sls", "Advanced option: URL to System Layout Service (SLS)")
@@ -24,6 +24,7 @@ package engine
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"sort"
@@ -38,6 +39,7 @@ import (
 	"github.com/Cray-HPE/hms-xname/xnametypes"
 	"github.com/mitchellh/mapstructure"
 	"gopkg.in/yaml.v2"
+	"inet.af/netaddr"
 )
 
 type TopologyEngine struct {
@@ -47,6 +49,13 @@ type TopologyEngine struct {
 type EngineInput struct {
 	Paddle                  ccj.Paddle
 	ApplicationNodeMetadata configs.ApplicationNodeMetadataMap
+	NIDAssignment           configs.NIDAssignment
+	AddressingPlan          configs.AddressingPlan
+	CabinetNetworkOverrides configs.CabinetNetworkOverrides
+
+	// Networks and subnets application nodes need IP reservations in, keyed by SubRole. If nil, then the default
+	// policy of UANs on the CAN and CHN is used.
+	ApplicationNetworkPolicy configs.ApplicationNetworkPolicy
 
 	// Advanced options to control when a the topology engine finds a despcrency.
 	IgnoreRemovedHardware                  bool
@@ -59,12 +68,29 @@ type EngineInput struct {
 type SubnetChange struct {
 	NetworkName string
 	Subnet      sls_common.IPV4Subnet
+
+	// IPv6 prefix given to the subnet, if the network is dual-stack
+	IPv6Prefix string
+}
+
+// StaticRangeExpansion records the static IP address range of a subnet being expanded to make room for new IP
+// reservations, which moves the start of the DHCP range.
+type StaticRangeExpansion struct {
+	NetworkName       string
+	SubnetName        string
+	PreviousDHCPStart string
+	DHCPStart         string
+	ExpandedBy        uint32
 }
+
 type IPReservationChange struct {
 	NetworkName   string
 	SubnetName    string
 	IPReservation sls_common.IPReservation
 
+	// IPv6 address of the reservation, if the network is dual-stack and the IPv6 address is recorded in SLS
+	IPv6Address string
+
 	// TODO have a better description of what caused the changed
 
 	// This is the hardware object that triggered the change
@@ -77,9 +103,23 @@ type TopologyChanges struct {
 	HardwareAdded    []sls_common.GenericHardware
 	ModifiedNetworks map[string]sls_common.Network
 
+	// Hardware in SLS that is missing from the CCJ. This is only populated when removed hardware is ignored, and the
+	// hardware is left in SLS.
+	HardwareRemoved []sls_common.GenericHardware
+
 	// The following fields are for book keeping to trigger other events
-	SubnetsAdded        []SubnetChange
-	IPReservationsAdded []IPReservationChange
+	SubnetsAdded          []SubnetChange
+	IPReservationsAdded   []IPReservationChange
+	StaticRangeExpansions []StaticRangeExpansion
+
+	// Findings of the NID audit of the system with the expected nodes from the CCJ merged in
+	NIDAudit sls.NIDAuditReport
+
+	// VLANs in use by more than one network in the current state of the system
+	DuplicateVLANs map[int16][]ipam.VLANUsage
+
+	// Hardware being added that does not have a BMC or controller connected to the HMN
+	DevicesWithoutHMNConnection []ccj.DeviceWithoutHMNConnection
 
 	// TODO Add in HSM EthernetEthernetInterface information
 	// This is needed if the state IP address range for a network needs to be expanded
@@ -95,7 +135,7 @@ func (te *TopologyEngine) DetermineChanges() (*TopologyChanges, error) {
 	// Build the Cabinet lookup structure from the provided CCJ
 	cabinetLookup, err := ccj.DetermineCabinetLookup(te.Input.Paddle)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build the cabinet lookup: %w", err)
+		return nil, &InputError{Err: fmt.Errorf("failed to build the cabinet lookup: %w", err)}
 	}
 
 	{
@@ -116,9 +156,21 @@ func (te *TopologyEngine) DetermineChanges() (*TopologyChanges, error) {
 	}
 
 	// Build up the expected SLS hardware state from the provided CCJ
-	expectedSLSState, err := ccj.BuildExpectedHardwareState(te.Input.Paddle, cabinetLookup, te.Input.ApplicationNodeMetadata, currentSwitchAliases, te.Input.IgnoreUnknownCANUHardwareArchitectures)
+	expectedSLSState, devicesWithoutHMNConnection, err := ccj.BuildExpectedHardwareState(te.Input.Paddle, cabinetLookup, te.Input.ApplicationNodeMetadata, te.Input.NIDAssignment, currentSwitchAliases, te.Input.IgnoreUnknownCANUHardwareArchitectures)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build expected SLS hardware state: %w", err)
+		return nil, &InputError{Err: fmt.Errorf("failed to build expected SLS hardware state: %w", err)}
+	}
+
+	// Audit the NIDs of the system with the expected nodes from the CCJ merged in.
+	// This needs to happen before Mountain hardware is pruned, as liquid-cooled compute nodes hold NIDs too.
+	nidAudit, err := auditNIDs(te.Input.CurrentSLSState.Hardware, expectedSLSState.Hardware, te.Input.NIDAssignment.AliasFormat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to audit NIDs: %w", err)
+	}
+
+	// Verify the NIDs of the expected compute nodes are unique, and are not already in use by other nodes in SLS.
+	if err := ccj.ValidateComputeNIDs(expectedSLSState.Hardware, te.Input.CurrentSLSState.Hardware); err != nil {
+		return nil, &InputError{Err: err}
 	}
 
 	// Prune Mountain hardware from current and expected state
@@ -182,6 +234,11 @@ func (te *TopologyEngine) DetermineChanges() (*TopologyChanges, error) {
 		return nil, err
 	}
 
+	// Only report the devices without a HMN connection that are being added to the system, so the operator can confirm
+	// that this is intentional.
+	devicesWithoutHMNConnection = filterDevicesWithoutHMNConnection(devicesWithoutHMNConnection, hardwareAdded)
+	displayDevicesWithoutHMNConnection(devicesWithoutHMNConnection)
+
 	//
 	// GUARD RAILS - If hardware is removed of has differing values then
 	// DO NOT PROCEED, as those are currently out of scope use cases.
@@ -189,11 +246,11 @@ func (te *TopologyEngine) DetermineChanges() (*TopologyChanges, error) {
 	// This is put in place as the first use for this tool is to add river cabinets to the system.
 	//
 	if len(hardwareRemoved) != 0 && !te.Input.IgnoreRemovedHardware {
-		return nil, fmt.Errorf("refusing to continue, found hardware was removed from the system. Please reconcile the current system state with the systems CCJ/SHCD")
+		return nil, &HardwareRemovedError{Hardware: hardwareRemoved}
 	}
 
 	if len(hardwareWithDifferingValues) != 0 {
-		return nil, fmt.Errorf("refusing to continue, found hardware with differing values (Class and/or ExtraProperties). Please reconcile the differences")
+		return nil, &HardwareDiffersError{Hardware: hardwareWithDifferingValues}
 	}
 
 	// TODO Verify that no hardware was moved, which would appear as a remove and add.
@@ -215,75 +272,169 @@ func (te *TopologyEngine) DetermineChanges() (*TopologyChanges, error) {
 		networkExtraProperties[networkName] = &ep
 	}
 
+	// Build up the VLANs in use across all networks, so new cabinet subnets do not reuse a VLAN used by another network
+	allNetworkExtraProperties := map[string]sls_common.NetworkExtraProperties{}
+	for networkName, ep := range networkExtraProperties {
+		allNetworkExtraProperties[networkName] = *ep
+	}
+	vlanRegistry := ipam.BuildVLANRegistry(allNetworkExtraProperties)
+
+	duplicateVLANs := vlanRegistry.Duplicates()
+	if len(duplicateVLANs) != 0 {
+		log.Println()
+		log.Println("Warning: Found VLANs in use by multiple networks")
+		for _, line := range vlanRegistry.DuplicatesSummary() {
+			log.Printf("  %s\n", line)
+		}
+	}
+
+	cabinetsAdded := map[string]bool{}
+	for _, hardware := range hardwareAdded {
+		if hardware.TypeString == xnametypes.Cabinet {
+			cabinetsAdded[hardware.Xname] = true
+		}
+	}
+
+	// Verify the cabinet network overrides are consistent with each other, and only refer to cabinets being added
+	if err := te.Input.CabinetNetworkOverrides.Validate(); err != nil {
+		return nil, &InputError{Err: fmt.Errorf("invalid cabinet network overrides: %w", err)}
+	}
+	for cabinetXname, networks := range te.Input.CabinetNetworkOverrides {
+		if !cabinetsAdded[cabinetXname] {
+			log.Printf("Warning: Ignoring cabinet network override for cabinet (%s) as it is not being added to the system\n", cabinetXname)
+		}
+
+		for networkName := range networks {
+			if _, present := networkExtraProperties[networkName]; !present {
+				return nil, &InputError{Err: fmt.Errorf("cabinet network override provided for cabinet (%s) in network (%s) that does not exist", cabinetXname, networkName)}
+			}
+		}
+	}
+
+	// Verify the addressing plan fits within the networks it is for
+	for networkName := range te.Input.AddressingPlan {
+		ep, present := networkExtraProperties[networkName]
+		if !present {
+			return nil, &InputError{Err: fmt.Errorf("addressing plan provided for network (%s) that does not exist", networkName)}
+		}
+
+		if err := te.Input.AddressingPlan.ForNetwork(networkName).Validate(ep.CIDR); err != nil {
+			return nil, &InputError{Err: fmt.Errorf("invalid addressing plan for network (%s): %w", networkName, err)}
+		}
+	}
+
 	// More bookkeeping to keep track of what network items have changed at a more granular level
 	subnetsAdded := []SubnetChange{}
 	ipReservationsAdded := []IPReservationChange{}
 
-	// First look for any new cabinets, and allocation an subnet for them
+	// First look for any new cabinets, and allocation an subnet for them.
+	// Cabinets with user specified network overrides are allocated first, so the automatically allocated subnets
+	// for the other cabinets do not take the VLANs or CIDRs meant for them.
 	// Note: The hardware being added is sorted by xname so this should be deterministic
+	var cabinetsToAllocate []int
 	for i, hardware := range hardwareAdded {
 		if hardware.TypeString == xnametypes.Cabinet {
-			// TODO In the case of added liquid-cooled cabinets the HMN_MTN or NMN_MTN networks may not exist.
-			// Such as the case of adding a liquid-cooled cabinet to a river only system.
-
-			// Allocation of the Cabinet Subnets
-			for _, networkPrefix := range []string{"HMN", "NMN"} {
-				networkName, err := determineCabinetNetwork(networkPrefix, hardware.Class)
-				if err != nil {
-					return nil, err
-				}
+			cabinetsToAllocate = append(cabinetsToAllocate, i)
+		}
+	}
+	sort.SliceStable(cabinetsToAllocate, func(i, j int) bool {
+		_, iHasOverride := te.Input.CabinetNetworkOverrides[hardwareAdded[cabinetsToAllocate[i]].Xname]
+		_, jHasOverride := te.Input.CabinetNetworkOverrides[hardwareAdded[cabinetsToAllocate[j]].Xname]
+		return iHasOverride && !jHasOverride
+	})
 
-				// Retrieve the network
-				networkExtraProperties, present := networkExtraProperties[networkName]
-				if !present {
-					return nil, fmt.Errorf("unable to allocate cabinet subnet network does not exist (%s)", networkName)
-				}
+	// The IPv6 prefixes already given to cabinets in dual-stack networks
+	ipv6PrefixesInUse, err := cabinetIPv6Prefixes(te.Input.CurrentSLSState.Hardware, networkExtraProperties)
+	if err != nil {
+		return nil, err
+	}
 
-				// Find an available subnet
-				xnameRaw := xnames.FromString(hardware.Xname)
-				xname, ok := xnameRaw.(xnames.Cabinet)
-				if !ok {
-					return nil, fmt.Errorf("unable to parse cabinet xname (%s)", hardware.Xname)
-				}
+	for _, i := range cabinetsToAllocate {
+		hardware := hardwareAdded[i]
 
-				subnet, err := ipam.AllocateCabinetSubnet(networkName, *networkExtraProperties, xname, nil)
-				if err != nil {
-					return nil, fmt.Errorf("unable to allocate subnet for cabinet (%s) in network (%s)", hardware.Xname, networkName)
-				}
+		// TODO In the case of added liquid-cooled cabinets the HMN_MTN or NMN_MTN networks may not exist.
+		// Such as the case of adding a liquid-cooled cabinet to a river only system.
 
-				// TODO Verify subnet VLAN is unique
+		// Allocation of the Cabinet Subnets
+		for _, networkPrefix := range []string{"HMN", "NMN"} {
+			networkName, err := determineCabinetNetwork(networkPrefix, hardware.Class)
+			if err != nil {
+				return nil, err
+			}
 
-				log.Printf("Allocated cabinet subnet %s with vlan %d in network %s for %s\n", subnet.CIDR, subnet.VlanID, networkName, hardware.Xname)
-				subnetsAdded = append(subnetsAdded, SubnetChange{
-					NetworkName: networkName,
-					Subnet:      subnet,
-				})
+			// Retrieve the network
+			networkExtraProperties, present := networkExtraProperties[networkName]
+			if !present {
+				return nil, fmt.Errorf("unable to allocate cabinet subnet network does not exist (%s)", networkName)
+			}
 
-				// Push in the newly created subnet into the SLS network
-				networkExtraProperties.Subnets = append(networkExtraProperties.Subnets, subnet)
-				modifiedNetworks[networkName] = true
+			// Find an available subnet
+			xnameRaw := xnames.FromString(hardware.Xname)
+			xname, ok := xnameRaw.(xnames.Cabinet)
+			if !ok {
+				return nil, fmt.Errorf("unable to parse cabinet xname (%s)", hardware.Xname)
+			}
 
-				// Update the cabinet hardware object to include the updated network info
-				extraProperties, ok := hardware.ExtraPropertiesRaw.(sls_common.ComptypeCabinet)
-				if !ok {
-					return nil, fmt.Errorf("cabinet (%s) is missing its extra properties structure", hardware.Xname)
+			// Use the user specified VLAN and CIDR for the cabinet subnet if provided
+			var vlanOverride *int16
+			var cidrOverride *netaddr.IPPrefix
+			if override, ok := te.Input.CabinetNetworkOverrides.Find(hardware.Xname, networkName); ok {
+				vlanOverride = &override.VlanID
+				if cidrOverride, err = override.Prefix(); err != nil {
+					return nil, &InputError{Err: fmt.Errorf("invalid cabinet network override for cabinet (%s) in network (%s): %w", hardware.Xname, networkName, err)}
 				}
 
-				// TODO This network information in the long term should not exist here in SLS.
-				extraProperties.Networks["cn"] = map[string]sls_common.CabinetNetworks{
-					"HMN": {
-						CIDR:    subnet.CIDR,
-						Gateway: subnet.Gateway.String(),
-						VLan:    int(subnet.VlanID),
-					},
+				log.Printf("Using cabinet network override for %s in network %s with vlan %d and CIDR (%s)\n", hardware.Xname, networkName, override.VlanID, override.CIDR)
+			}
+
+			subnet, err := ipam.AllocateCabinetSubnet(networkName, *networkExtraProperties, xname, vlanOverride, cidrOverride, te.Input.AddressingPlan.ForNetwork(networkName), vlanRegistry)
+			if err != nil {
+				err = fmt.Errorf("unable to allocate subnet for cabinet (%s) in network (%s): %w", hardware.Xname, networkName, err)
+
+				// The cabinet network override provided by the user cannot be used
+				var overrideErr *ipam.OverrideError
+				if errors.As(err, &overrideErr) {
+					return nil, &InputError{Err: err}
 				}
+				return nil, err
+			}
 
-				if hardware.Class == sls_common.ClassRiver {
-					extraProperties.Networks["ncn"] = extraProperties.Networks["cn"]
+			// Record the VLAN of the new subnet, so it is not allocated again for another network
+			vlanRegistry.Add(subnet.VlanID, ipam.VLANUsage{Network: networkName, Subnet: subnet.Name})
+
+			log.Printf("Allocated cabinet subnet %s with vlan %d in network %s for %s\n", subnet.CIDR, subnet.VlanID, networkName, hardware.Xname)
+
+			// Carve out an IPv6 prefix for the cabinet if the network is dual-stack
+			var ipv6Prefix string
+			if addressingPlan := te.Input.AddressingPlan.ForNetwork(networkName); addressingPlan.IPv6CIDR != "" {
+				prefix, err := allocateCabinetIPv6Prefix(addressingPlan, ipv6PrefixesInUse)
+				if err != nil {
+					return nil, fmt.Errorf("unable to allocate IPv6 prefix for cabinet (%s) in network (%s): %w", hardware.Xname, networkName, err)
 				}
 
-				hardwareAdded[i] = hardware
+				ipv6PrefixesInUse = append(ipv6PrefixesInUse, prefix)
+				ipv6Prefix = prefix.String()
+				sls.SetSubnetIPv6Prefix(&subnet, prefix)
+				log.Printf("Allocated cabinet IPv6 prefix %s in network %s for %s\n", ipv6Prefix, networkName, hardware.Xname)
 			}
+
+			subnetsAdded = append(subnetsAdded, SubnetChange{
+				NetworkName: networkName,
+				Subnet:      subnet,
+				IPv6Prefix:  ipv6Prefix,
+			})
+
+			// Push in the newly created subnet into the SLS network
+			networkExtraProperties.Subnets = append(networkExtraProperties.Subnets, subnet)
+			modifiedNetworks[networkName] = true
+
+			// Update the cabinet hardware object to include the updated network info
+			hardware, err = setCabinetNetwork(hardware, networkPrefix, subnet, ipv6Prefix)
+			if err != nil {
+				return nil, err
+			}
+
+			hardwareAdded[i] = hardware
 		}
 	}
 
@@ -345,10 +496,25 @@ func (te *TopologyEngine) DetermineChanges() (*TopologyChanges, error) {
 				}
 
 				log.Printf("%s (%s): Allocated IP %s in subnet network_hardware in network %s\n", hardware.Xname, aliases[0], ipReservation.IPAddress.String(), networkName)
+
+				// Only the HMN IP of management switches has a place in SLS for an IPv6 address, which is the IP6addr
+				// field of the switch
+				var ipv6Address string
+				if networkName == "HMN" && (hmsType == xnametypes.MgmtSwitch || hmsType == xnametypes.MgmtHLSwitch) {
+					ipv6Address, err = determineIPv6Address(te.Input.AddressingPlan, networkName, *networkExtraProperties, ipReservation)
+					if err != nil {
+						return nil, fmt.Errorf("unable to determine IPv6 address for switch (%s) in network (%s): %w", xname.String(), networkName, err)
+					}
+				}
+				if ipv6Address != "" {
+					log.Printf("%s (%s): Determined IPv6 address %s in network %s\n", hardware.Xname, aliases[0], ipv6Address, networkName)
+				}
+
 				ipReservationsAdded = append(ipReservationsAdded, IPReservationChange{
 					NetworkName:    networkName,
 					SubnetName:     "network_hardware",
 					IPReservation:  ipReservation,
+					IPv6Address:    ipv6Address,
 					ChangedByXname: hardware.Xname,
 				})
 
@@ -366,6 +532,7 @@ func (te *TopologyEngine) DetermineChanges() (*TopologyChanges, error) {
 					}
 
 					extraProperties.IP4Addr = ipReservation.IPAddress.String()
+					extraProperties.IP6Addr = ipv6Address
 
 					// Push the updated extra properties back into the list of new hardware
 					hardware.ExtraPropertiesRaw = extraProperties
@@ -381,6 +548,7 @@ func (te *TopologyEngine) DetermineChanges() (*TopologyChanges, error) {
 					}
 
 					extraProperties.IP4Addr = ipReservation.IPAddress.String()
+					extraProperties.IP6Addr = ipv6Address
 
 					// Push the updated extra properties back into the list of new hardware
 					hardware.ExtraPropertiesRaw = extraProperties
@@ -391,16 +559,24 @@ func (te *TopologyEngine) DetermineChanges() (*TopologyChanges, error) {
 		}
 	}
 
-	// Allocate UAN IPs on the CAN or CHN
+	// Allocate application node IPs on the networks given by the application network policy, such as UANs on the CAN
+	// or CHN.
 	// UH-OH the CAN/CHN range tightly packs the Static and DHCP IP address ranges right next to each other.
 	// So if we need to allocate an UAN IP on the CHN, then the Static IP address range needs to be expanded.
 	// Since this in on the CAN/CHN no nodes will be using these IPs for booting over DVS (either NMN or HSM)
 	// This will make adjusting the DHCP range nicer.
-	type uanInfo struct {
-		xname string
-		alias string
+	applicationNetworkPolicy := te.Input.ApplicationNetworkPolicy
+	if applicationNetworkPolicy == nil {
+		applicationNetworkPolicy = configs.DefaultApplicationNetworkPolicy
 	}
-	var uans []uanInfo
+
+	type applicationNodeInfo struct {
+		xname    string
+		alias    string
+		subRole  string
+		networks []configs.ApplicationNetworkSubnet
+	}
+	var applicationNodes []applicationNodeInfo
 	for _, hardware := range hardwareAdded {
 		if hardware.TypeString != xnametypes.Node {
 			continue
@@ -411,110 +587,156 @@ func (te *TopologyEngine) DetermineChanges() (*TopologyChanges, error) {
 			return nil, fmt.Errorf("unable to decode extra properties for (%s)", hardware.Xname)
 		}
 
-		if extraProperties.Role == "Application" && extraProperties.SubRole == "UAN" {
-			if len(extraProperties.Aliases) == 0 {
-				return nil, fmt.Errorf("no aliases defined for (%s)", hardware.Xname)
-			}
+		if extraProperties.Role != "Application" {
+			continue
+		}
 
-			uans = append(uans, uanInfo{
-				xname: hardware.Xname,
-				alias: extraProperties.Aliases[0],
-			})
+		networks := applicationNetworkPolicy.ForSubRole(extraProperties.SubRole)
+		if len(networks) == 0 {
+			continue
 		}
+
+		if len(extraProperties.Aliases) == 0 {
+			return nil, fmt.Errorf("no aliases defined for (%s)", hardware.Xname)
+		}
+
+		applicationNodes = append(applicationNodes, applicationNodeInfo{
+			xname:    hardware.Xname,
+			alias:    extraProperties.Aliases[0],
+			subRole:  extraProperties.SubRole,
+			networks: networks,
+		})
 	}
 
-	// Sort the UANs to add them increasing order to be deterministic
-	sort.SliceStable(uans, func(i, j int) bool {
-		return uans[i].alias < uans[j].alias
+	// Sort the application nodes to add them increasing order to be deterministic
+	sort.SliceStable(applicationNodes, func(i, j int) bool {
+		return applicationNodes[i].alias < applicationNodes[j].alias
 	})
 
-	if len(uans) != 0 {
-		// Check to see if the Static IP address range in the CAN/CHN networks needs to be expanded
-		// to accommodate the new UANs.
-		for _, networkName := range []string{"CAN", "CHN"} {
-			// Only allocate an IP for the UAN if the network exists
-			networkExtraProperties, present := networkExtraProperties[networkName]
+	// Count the number of IP reservations needed within each subnet by the application nodes of all SubRoles. Subnets
+	// of networks that do not exist are skipped, and application nodes with an existing IP reservation do not need
+	// another one.
+	var requiredSubnets []configs.ApplicationNetworkSubnet
+	requiredIPCount := map[configs.ApplicationNetworkSubnet]uint32{}
+	for _, applicationNode := range applicationNodes {
+		for _, networkSubnet := range applicationNode.networks {
+			// Only allocate an IP for the application node if the network exists
+			networkExtraProperties, present := networkExtraProperties[networkSubnet.Network]
 			if !present {
 				continue
 			}
-			log.Printf("Checking to see if the static IP address range for the bootstrap_dhcp subnet in %s has enough room for added UAN(s).\n", networkName)
 
-			// Retrieve the subnet
-			slsSubnet, slsSubnetIndex, err := networkExtraProperties.LookupSubnet("bootstrap_dhcp")
-			if !present {
-				return nil, fmt.Errorf("unable to find subnet in (%s) network: %w", networkName, err)
+			slsSubnet, _, err := networkExtraProperties.LookupSubnet(networkSubnet.Subnet)
+			if err != nil {
+				return nil, fmt.Errorf("unable to find subnet (%s) in (%s) network for application node %s (%s) with SubRole (%s): %w", networkSubnet.Subnet, networkSubnet.Network, applicationNode.xname, applicationNode.alias, applicationNode.subRole, err)
 			}
 
-			freeIPCount, err := ipam.FreeIPsInStaticRange(slsSubnet)
-			if err != nil {
-				return nil, fmt.Errorf("unable to determine the number of free IPs in the Static IP range in bootstrap_dhcp subnet in (%s) network: %w", networkName, err)
+			if _, ok := slsSubnet.ReservationsByName()[applicationNode.alias]; ok {
+				continue
 			}
 
-			var expandStaticRangeBy uint32
-			if freeIPCount < uint32(len(uans)) {
-				expandStaticRangeBy = uint32(len(uans)) - freeIPCount
-				log.Printf("The bootstrap_dhcp subnet in %s network has %d IP addresses available, will be expanded by %d hosts.\n", networkName, freeIPCount, expandStaticRangeBy)
+			if _, ok := requiredIPCount[networkSubnet]; !ok {
+				requiredSubnets = append(requiredSubnets, networkSubnet)
+			}
+			requiredIPCount[networkSubnet]++
+		}
+	}
 
-				// Okay, lets see if we can expand the subnet by the number of UANs being added to the system
-				if err := ipam.ExpandSubnetStaticRange(&slsSubnet, uint32(len(uans))); err != nil {
-					return nil, fmt.Errorf("unable to expand the static IP address range in the bootstrap_dhcp subnet in (%s) network: %w", networkName, err)
-				}
-				log.Printf("The bootstrap_dhcp subnet in %s network has been expanded by %d IP addresses,\n", networkName, expandStaticRangeBy)
+	// Check to see if the Static IP address range of each subnet needs to be expanded to accommodate the new
+	// application nodes.
+	var staticRangeExpansions []StaticRangeExpansion
+	for _, networkSubnet := range requiredSubnets {
+		networkName, subnetName := networkSubnet.Network, networkSubnet.Subnet
+		networkExtraProperties := networkExtraProperties[networkName]
+		log.Printf("Checking to see if the static IP address range for the %s subnet in %s has enough room for added application node(s).\n", subnetName, networkName)
 
-				// Update the subnet with the new DHCP range
-				networkExtraProperties.Subnets[slsSubnetIndex] = slsSubnet
-				modifiedNetworks[networkName] = true
-			} else {
-				log.Printf("The bootstrap_dhcp subnet in %s network has %d IP addresses available.\n", networkName, freeIPCount)
+		// Retrieve the subnet
+		slsSubnet, slsSubnetIndex, err := networkExtraProperties.LookupSubnet(subnetName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find subnet in (%s) network: %w", networkName, err)
+		}
+
+		if slsSubnet.DHCPStart == nil {
+			// Without a DHCP range the whole subnet is available for static IPs
+			continue
+		}
+
+		freeIPCount, err := ipam.FreeIPsInStaticRange(slsSubnet)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine the number of free IPs in the Static IP range in %s subnet in (%s) network: %w", subnetName, networkName, err)
+		}
+
+		if freeIPCount < requiredIPCount[networkSubnet] {
+			expandStaticRangeBy := requiredIPCount[networkSubnet] - freeIPCount
+			log.Printf("The %s subnet in %s network has %d IP addresses available, will be expanded by %d hosts.\n", subnetName, networkName, freeIPCount, expandStaticRangeBy)
+
+			// Okay, lets see if we can expand the subnet by the number of application nodes being added to the system
+			previousDHCPStart := slsSubnet.DHCPStart.String()
+			if err := ipam.ExpandSubnetStaticRange(&slsSubnet, expandStaticRangeBy); err != nil {
+				return nil, fmt.Errorf("unable to expand the static IP address range in the %s subnet in (%s) network: %w", subnetName, networkName, err)
 			}
+			log.Printf("The %s subnet in %s network has been expanded by %d IP addresses,\n", subnetName, networkName, expandStaticRangeBy)
 
+			// Update the subnet with the new DHCP range
+			networkExtraProperties.Subnets[slsSubnetIndex] = slsSubnet
+			modifiedNetworks[networkName] = true
+
+			staticRangeExpansions = append(staticRangeExpansions, StaticRangeExpansion{
+				NetworkName:       networkName,
+				SubnetName:        subnetName,
+				PreviousDHCPStart: previousDHCPStart,
+				DHCPStart:         slsSubnet.DHCPStart.String(),
+				ExpandedBy:        expandStaticRangeBy,
+			})
+		} else {
+			log.Printf("The %s subnet in %s network has %d IP addresses available.\n", subnetName, networkName, freeIPCount)
 		}
 	}
 
 	// Allocate IP addresses
-	for _, uan := range uans {
-		log.Printf("%s (%s): Allocating IPs For UAN\n", uan.xname, uan.alias)
+	for _, applicationNode := range applicationNodes {
+		log.Printf("%s (%s): Allocating IPs For %s application node\n", applicationNode.xname, applicationNode.alias, applicationNode.subRole)
 
-		for _, networkName := range []string{"CAN", "CHN"} {
-			// Only allocate an IP for the UAN if the network exists
+		for _, networkSubnet := range applicationNode.networks {
+			networkName, subnetName := networkSubnet.Network, networkSubnet.Subnet
+
+			// Only allocate an IP for the application node if the network exists
 			networkExtraProperties, present := networkExtraProperties[networkName]
 			if !present {
 				continue
 			}
 
-			// Debug
-			//log.Printf("%s (%s): Allocating IP on the %s network\n", uan.xname, uan.alias, networkName)
-
 			// Retrieve the subnet
-			slsSubnet, slsSubnetIndex, err := networkExtraProperties.LookupSubnet("bootstrap_dhcp")
-			if !present {
+			slsSubnet, slsSubnetIndex, err := networkExtraProperties.LookupSubnet(subnetName)
+			if err != nil {
 				return nil, fmt.Errorf("unable to find subnet in (%s) network: %w", networkName, err)
 			}
 
 			// Parse the xname
-			xname := xnames.FromString(uan.xname)
+			xname := xnames.FromString(applicationNode.xname)
 			if xname == nil {
-				return nil, fmt.Errorf("unable to parse UAN xname (%s)", uan.xname)
+				return nil, fmt.Errorf("unable to parse application node xname (%s)", applicationNode.xname)
 			}
 
-			if existingIPReservation, ok := slsSubnet.ReservationsByName()[uan.alias]; ok {
-				log.Printf("%s (%s): Found existing IP allocation %s on the %s network\n", uan.xname, uan.alias, existingIPReservation.IPAddress, networkName)
+			if existingIPReservation, ok := slsSubnet.ReservationsByName()[applicationNode.alias]; ok {
+				log.Printf("%s (%s): Found existing IP allocation %s in the %s subnet on the %s network\n", applicationNode.xname, applicationNode.alias, existingIPReservation.IPAddress, subnetName, networkName)
 				continue
 			}
 
 			// Allocate the IP!
-			ipReservation, err := ipam.AllocateIP(slsSubnet, xname, uan.alias)
+			ipReservation, err := ipam.AllocateIP(slsSubnet, xname, applicationNode.alias)
 			if err != nil {
-				return nil, fmt.Errorf("unable to allocate IP for UAN %s (%s) in network (%s): %w", xname.String(), uan.alias, networkName, err)
+				return nil, fmt.Errorf("unable to allocate IP for application node %s (%s) in subnet (%s) in network (%s): %w", xname.String(), applicationNode.alias, subnetName, networkName, err)
 			}
+
 			ipReservationsAdded = append(ipReservationsAdded, IPReservationChange{
 				NetworkName:    networkName,
-				SubnetName:     "bootstrap_dhcp",
+				SubnetName:     subnetName,
 				IPReservation:  ipReservation,
-				ChangedByXname: uan.xname,
+				ChangedByXname: applicationNode.xname,
 			})
 
-			log.Printf("%s (%s): Allocated IP %s on the %s network\n", uan.xname, uan.alias, ipReservation.IPAddress, networkName)
+			log.Printf("%s (%s): Allocated IP %s in the %s subnet on the %s network\n", applicationNode.xname, applicationNode.alias, ipReservation.IPAddress, subnetName, networkName)
 
 			// Push in the network IP Reservation into the subnet
 			slsSubnet.IPReservations = append(slsSubnet.IPReservations, ipReservation)
@@ -531,11 +753,18 @@ func (te *TopologyEngine) DetermineChanges() (*TopologyChanges, error) {
 			continue
 		}
 
+		// Update the VLAN and IP ranges of the network to cover any new subnets
+		networkExtraProperties.VlanRange = sls.NetworkVlanRange(*networkExtraProperties)
+
 		// Merge extra properties with the top level network with SLS
 		slsNetwork := te.Input.CurrentSLSState.Networks[networkName]
 		slsNetwork.ExtraPropertiesRaw = networkExtraProperties
 
-		// TODO update vlan range.
+		ipRanges, err := sls.NetworkIPRanges(slsNetwork.IPRanges, *networkExtraProperties)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine IP ranges for network (%s): %w", networkName, err)
+		}
+		slsNetwork.IPRanges = ipRanges
 
 		modifiedNetworksSet[networkName] = slsNetwork
 	}
@@ -543,12 +772,190 @@ func (te *TopologyEngine) DetermineChanges() (*TopologyChanges, error) {
 	return &TopologyChanges{
 		HardwareAdded:    hardwareAdded,
 		ModifiedNetworks: modifiedNetworksSet,
+		HardwareRemoved:  hardwareRemoved,
+
+		SubnetsAdded:          subnetsAdded,
+		IPReservationsAdded:   ipReservationsAdded,
+		StaticRangeExpansions: staticRangeExpansions,
 
-		SubnetsAdded:        subnetsAdded,
-		IPReservationsAdded: ipReservationsAdded,
+		NIDAudit:                    nidAudit,
+		DevicesWithoutHMNConnection: devicesWithoutHMNConnection,
+		DuplicateVLANs:              duplicateVLANs,
 	}, nil
 }
 
+// setCabinetNetwork will record the subnet of the cabinet in the given network (HMN or NMN) in the network information
+// of the cabinet, leaving the information for its other networks in place.
+func setCabinetNetwork(hardware sls_common.GenericHardware, networkPrefix string, subnet sls_common.IPV4Subnet, ipv6Prefix string) (sls_common.GenericHardware, error) {
+	extraProperties, ok := hardware.ExtraPropertiesRaw.(sls_common.ComptypeCabinet)
+	if !ok {
+		return hardware, fmt.Errorf("cabinet (%s) is missing its extra properties structure", hardware.Xname)
+	}
+
+	// TODO This network information in the long term should not exist here in SLS.
+	if extraProperties.Networks == nil {
+		extraProperties.Networks = map[string]map[string]sls_common.CabinetNetworks{}
+	}
+	if extraProperties.Networks["cn"] == nil {
+		extraProperties.Networks["cn"] = map[string]sls_common.CabinetNetworks{}
+	}
+
+	extraProperties.Networks["cn"][networkPrefix] = sls_common.CabinetNetworks{
+		CIDR:       subnet.CIDR,
+		Gateway:    subnet.Gateway.String(),
+		VLan:       int(subnet.VlanID),
+		IPv6Prefix: ipv6Prefix,
+	}
+
+	if hardware.Class == sls_common.ClassRiver {
+		extraProperties.Networks["ncn"] = extraProperties.Networks["cn"]
+	}
+
+	hardware.ExtraPropertiesRaw = extraProperties
+	return hardware, nil
+}
+
+// allocateCabinetIPv6Prefix will carve out an IPv6 prefix for a cabinet subnet from the IPv6 CIDR of a dual-stack
+// network.
+func allocateCabinetIPv6Prefix(addressingPlan configs.SubnetAddressingPlan, prefixesInUse []netaddr.IPPrefix) (netaddr.IPPrefix, error) {
+	ipv6Network, err := netaddr.ParseIPPrefix(addressingPlan.IPv6CIDR)
+	if err != nil {
+		return netaddr.IPPrefix{}, fmt.Errorf("failed to parse IPv6 CIDR (%s): %w", addressingPlan.IPv6CIDR, err)
+	}
+
+	return ipam.AllocateIPv6Prefix(ipv6Network, prefixesInUse, addressingPlan.IPv6PrefixLength)
+}
+
+// cabinetIPv6Prefixes will find the IPv6 prefixes already given to cabinets, either in the network information of the
+// cabinet or recorded on the cabinet subnets of the networks.
+func cabinetIPv6Prefixes(allHardware map[string]sls_common.GenericHardware, networks map[string]*sls_common.NetworkExtraProperties) ([]netaddr.IPPrefix, error) {
+	var prefixes []netaddr.IPPrefix
+	for _, hardware := range allHardware {
+		if hardware.TypeString != xnametypes.Cabinet {
+			continue
+		}
+
+		var extraProperties sls_common.ComptypeCabinet
+		if err := mapstructure.Decode(hardware.ExtraPropertiesRaw, &extraProperties); err != nil {
+			return nil, fmt.Errorf("unable to decode extra properties for (%s)", hardware.Xname)
+		}
+
+		for _, networks := range extraProperties.Networks {
+			for _, network := range networks {
+				if network.IPv6Prefix == "" {
+					continue
+				}
+
+				prefix, err := netaddr.ParseIPPrefix(network.IPv6Prefix)
+				if err != nil {
+					return nil, fmt.Errorf("unable to parse IPv6 prefix (%s) of cabinet (%s): %w", network.IPv6Prefix, hardware.Xname, err)
+				}
+				prefixes = append(prefixes, prefix)
+			}
+		}
+	}
+
+	for networkName, networkExtraProperties := range networks {
+		for _, subnet := range networkExtraProperties.Subnets {
+			prefix, ok, err := sls.SubnetIPv6Prefix(subnet)
+			if err != nil {
+				return nil, fmt.Errorf("network (%s): %w", networkName, err)
+			}
+			if ok {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+	}
+
+	return prefixes, nil
+}
+
+// determineIPv6Address will determine the IPv6 address matching an IPv4 reservation in a dual-stack network. If the
+// network is not dual-stack, then an empty string is returned.
+func determineIPv6Address(addressingPlan configs.AddressingPlan, networkName string, networkExtraProperties sls_common.NetworkExtraProperties, ipReservation sls_common.IPReservation) (string, error) {
+	ipv6CIDR := addressingPlan.ForNetwork(networkName).IPv6CIDR
+	if ipv6CIDR == "" {
+		return "", nil
+	}
+
+	ipv4Network, err := netaddr.ParseIPPrefix(networkExtraProperties.CIDR)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse network CIDR (%s): %w", networkExtraProperties.CIDR, err)
+	}
+
+	ipv6Network, err := netaddr.ParseIPPrefix(ipv6CIDR)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse IPv6 CIDR (%s): %w", ipv6CIDR, err)
+	}
+
+	ipv6Reservation, err := ipam.IPv6Reservation(ipv4Network, ipReservation, ipv6Network)
+	if err != nil {
+		return "", err
+	}
+
+	return ipv6Reservation.IPAddress.String(), nil
+}
+
+// filterDevicesWithoutHMNConnection will only keep the devices that are being added to the system.
+func filterDevicesWithoutHMNConnection(devices []ccj.DeviceWithoutHMNConnection, hardwareAdded []sls_common.GenericHardware) []ccj.DeviceWithoutHMNConnection {
+	hardwareAddedLookup := map[string]bool{}
+	for _, hardware := range hardwareAdded {
+		hardwareAddedLookup[hardware.Xname] = true
+	}
+
+	var result []ccj.DeviceWithoutHMNConnection
+	for _, device := range devices {
+		if hardwareAddedLookup[device.Xname] {
+			result = append(result, device)
+		}
+	}
+
+	return result
+}
+
+func displayDevicesWithoutHMNConnection(devices []ccj.DeviceWithoutHMNConnection) {
+	if len(devices) == 0 {
+		return
+	}
+
+	log.Println()
+	log.Println("Hardware being added without a connection to the HMN")
+	for _, device := range devices {
+		log.Printf("  %-16s - %s (%s)\n", device.Xname, device.CommonName, device.Architecture)
+	}
+}
+
+// auditNIDs will audit the NIDs of the current hardware with the nodes from the expected hardware merged in.
+func auditNIDs(currentHardware, expectedHardware map[string]sls_common.GenericHardware, aliasFormat string) (sls.NIDAuditReport, error) {
+	allHardware := map[string]sls_common.GenericHardware{}
+	for xname, hardware := range currentHardware {
+		allHardware[xname] = hardware
+	}
+	for xname, hardware := range expectedHardware {
+		if hardware.TypeString == xnametypes.Node {
+			allHardware[xname] = hardware
+		}
+	}
+
+	report, err := sls.AuditNIDs(allHardware, aliasFormat)
+	if err != nil {
+		return sls.NIDAuditReport{}, err
+	}
+
+	log.Println()
+	log.Println("NID audit of the current and expected nodes")
+	summary := report.Summary()
+	if len(summary) == 0 {
+		log.Println("  None")
+	}
+	for _, line := range summary {
+		log.Printf("  %s\n", line)
+	}
+	log.Println()
+
+	return report, nil
+}
+
 func displayHardwareComparisonReport(hardwareRemoved, hardwareAdded, identicalHardware []sls_common.GenericHardware, hardwareWithDifferingValues []sls.GenericHardwarePair) error {
 	log.Println()
 	log.Println("Identical hardware between current and expected states")
@@ -0,0 +1,142 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package engine
+
+import (
+	"net"
+	"testing"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/sls"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/Cray-HPE/hms-xname/xnametypes"
+	"github.com/stretchr/testify/suite"
+	"inet.af/netaddr"
+)
+
+type CabinetIPv6TestSuite struct {
+	suite.Suite
+}
+
+func (suite *CabinetIPv6TestSuite) cabinet(xname string) sls_common.GenericHardware {
+	return sls_common.GenericHardware{
+		Xname:              xname,
+		Type:               sls_common.Cabinet,
+		TypeString:         xnametypes.Cabinet,
+		Class:              sls_common.ClassRiver,
+		ExtraPropertiesRaw: sls_common.ComptypeCabinet{},
+	}
+}
+
+// allocateCabinets mimics the cabinet subnet allocation of the engine for the HMN and NMN networks, which share the
+// same IPv6 CIDR.
+func (suite *CabinetIPv6TestSuite) allocateCabinets(hardware map[string]sls_common.GenericHardware, networks map[string]*sls_common.NetworkExtraProperties, xnames ...string) []string {
+	addressingPlan := configs.SubnetAddressingPlan{IPv6CIDR: "fd00:0:0:100::/56"}.WithDefaults()
+
+	prefixesInUse, err := cabinetIPv6Prefixes(hardware, networks)
+	suite.Require().NoError(err)
+
+	var allocated []string
+	for _, xname := range xnames {
+		cabinet := suite.cabinet(xname)
+		for _, networkPrefix := range []string{"HMN", "NMN"} {
+			networkName := networkPrefix + "_RVR"
+
+			prefix, err := allocateCabinetIPv6Prefix(addressingPlan, prefixesInUse)
+			suite.Require().NoError(err)
+			prefixesInUse = append(prefixesInUse, prefix)
+			allocated = append(allocated, prefix.String())
+
+			subnet := sls_common.IPV4Subnet{
+				Name:    "cabinet_" + xname[1:],
+				CIDR:    "10.107.0.0/22",
+				Gateway: net.ParseIP("10.107.0.1"),
+				VlanID:  1513,
+			}
+			sls.SetSubnetIPv6Prefix(&subnet, prefix)
+			networks[networkName].Subnets = append(networks[networkName].Subnets, subnet)
+
+			cabinet, err = setCabinetNetwork(cabinet, networkPrefix, subnet, prefix.String())
+			suite.Require().NoError(err)
+		}
+
+		hardware[xname] = cabinet
+	}
+
+	return allocated
+}
+
+func (suite *CabinetIPv6TestSuite) TestSetCabinetNetworkKeepsOtherNetworks() {
+	cabinet := suite.cabinet("x3000")
+
+	cabinet, err := setCabinetNetwork(cabinet, "HMN", sls_common.IPV4Subnet{CIDR: "10.107.0.0/22", Gateway: net.ParseIP("10.107.0.1"), VlanID: 1513}, "fd00:0:0:100::/64")
+	suite.NoError(err)
+	cabinet, err = setCabinetNetwork(cabinet, "NMN", sls_common.IPV4Subnet{CIDR: "10.106.0.0/22", Gateway: net.ParseIP("10.106.0.1"), VlanID: 1770}, "fd00:0:0:101::/64")
+	suite.NoError(err)
+
+	extraProperties := cabinet.ExtraPropertiesRaw.(sls_common.ComptypeCabinet)
+	suite.Equal(map[string]sls_common.CabinetNetworks{
+		"HMN": {CIDR: "10.107.0.0/22", Gateway: "10.107.0.1", VLan: 1513, IPv6Prefix: "fd00:0:0:100::/64"},
+		"NMN": {CIDR: "10.106.0.0/22", Gateway: "10.106.0.1", VLan: 1770, IPv6Prefix: "fd00:0:0:101::/64"},
+	}, extraProperties.Networks["cn"])
+	suite.Equal(extraProperties.Networks["cn"], extraProperties.Networks["ncn"])
+}
+
+func (suite *CabinetIPv6TestSuite) TestAllocationTwiceOverSameState() {
+	hardware := map[string]sls_common.GenericHardware{}
+	networks := map[string]*sls_common.NetworkExtraProperties{
+		"HMN_RVR": {},
+		"NMN_RVR": {},
+	}
+
+	// The first run adds a cabinet, and the second run over the resulting SLS state adds another
+	first := suite.allocateCabinets(hardware, networks, "x3000")
+	second := suite.allocateCabinets(hardware, networks, "x3001")
+
+	suite.Equal([]string{"fd00:0:0:100::/64", "fd00:0:0:101::/64"}, first)
+	suite.Equal([]string{"fd00:0:0:102::/64", "fd00:0:0:103::/64"}, second)
+
+	prefixesInUse, err := cabinetIPv6Prefixes(hardware, networks)
+	suite.NoError(err)
+
+	seen := map[netaddr.IPPrefix]bool{}
+	for _, prefix := range prefixesInUse {
+		seen[prefix] = true
+	}
+	suite.Len(seen, 4)
+}
+
+func (suite *CabinetIPv6TestSuite) TestPrefixOnlyRecordedOnSubnet() {
+	// A cabinet subnet with an IPv6 prefix whose cabinet network information was lost still keeps the prefix in use
+	networks := map[string]*sls_common.NetworkExtraProperties{
+		"HMN_RVR": {Subnets: []sls_common.IPV4Subnet{{Name: "cabinet_3000", Comment: "ipv6_prefix=fd00:0:0:100::/64"}}},
+	}
+
+	prefixesInUse, err := cabinetIPv6Prefixes(map[string]sls_common.GenericHardware{}, networks)
+	suite.NoError(err)
+	suite.Equal([]netaddr.IPPrefix{netaddr.MustParseIPPrefix("fd00:0:0:100::/64")}, prefixesInUse)
+}
+
+func TestCabinetIPv6TestSuite(t *testing.T) {
+	suite.Run(t, new(CabinetIPv6TestSuite))
+}
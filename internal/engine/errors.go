@@ -0,0 +1,84 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package engine
+
+import (
+	"errors"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/ipam"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/sls"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+)
+
+var (
+	// ErrHardwareRemoved is wrapped by the error returned when hardware in SLS is missing from the CCJ.
+	ErrHardwareRemoved = errors.New("hardware was removed from the system")
+
+	// ErrHardwareDiffers is wrapped by the error returned when hardware in SLS differs from the hardware in the CCJ.
+	ErrHardwareDiffers = errors.New("hardware has differing values")
+
+	// ErrNetworkExhausted and ErrSubnetExhausted are wrapped by the errors returned when a cabinet subnet or IP
+	// address cannot be allocated for new hardware.
+	ErrNetworkExhausted = ipam.ErrNetworkExhausted
+	ErrSubnetExhausted  = ipam.ErrSubnetExhausted
+)
+
+// HardwareRemovedError is returned when the engine refuses to continue because hardware was removed from the system.
+type HardwareRemovedError struct {
+	Hardware []sls_common.GenericHardware
+}
+
+func (e *HardwareRemovedError) Error() string {
+	return "refusing to continue, found hardware was removed from the system. Please reconcile the current system state with the systems CCJ/SHCD"
+}
+
+func (e *HardwareRemovedError) Unwrap() error {
+	return ErrHardwareRemoved
+}
+
+// HardwareDiffersError is returned when the engine refuses to continue because hardware has a different Class or
+// ExtraProperties in SLS than expected from the CCJ.
+type HardwareDiffersError struct {
+	Hardware []sls.GenericHardwarePair
+}
+
+func (e *HardwareDiffersError) Error() string {
+	return "refusing to continue, found hardware with differing values (Class and/or ExtraProperties). Please reconcile the differences"
+}
+
+func (e *HardwareDiffersError) Unwrap() error {
+	return ErrHardwareDiffers
+}
+
+// InputError is returned when the input of the engine, such as the CCJ or one of the configuration files, is invalid.
+type InputError struct {
+	Err error
+}
+
+func (e *InputError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InputError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,372 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/internal/engine"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/bss"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"gopkg.in/yaml.v2"
+)
+
+// SchemaVersion is the version of the report schema. It is only changed when a field is removed or changes meaning,
+// new fields may be added to the same version.
+const SchemaVersion = "v1"
+
+type Status string
+
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusRefused   Status = "refused"
+	StatusFailed    Status = "failed"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// ParseFormat will verify the given output format is supported.
+func ParseFormat(format string) (Format, error) {
+	switch Format(format) {
+	case FormatJSON, FormatYAML:
+		return Format(format), nil
+	default:
+		return "", fmt.Errorf("unsupported output format (%s), expected json or yaml", format)
+	}
+}
+
+// Report is a machine readable summary of the changes made, or that would be made during a dry run, by a single run
+// of the update command.
+type Report struct {
+	SchemaVersion string `json:"schema_version" yaml:"schema_version"`
+	DryRun        bool   `json:"dry_run" yaml:"dry_run"`
+	Status        Status `json:"status" yaml:"status"`
+	Error         string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	Hardware HardwareChanges `json:"hardware" yaml:"hardware"`
+	Networks NetworkChanges  `json:"networks" yaml:"networks"`
+	BSS      []BSSEntry      `json:"bss" yaml:"bss"`
+
+	Applied AppliedChanges `json:"applied" yaml:"applied"`
+}
+
+// AppliedChanges records which writes to SLS and BSS were applied before the run ended. When a run fails part way
+// through, this shows which changes still need to be made.
+type AppliedChanges struct {
+	SLS bool     `json:"sls" yaml:"sls"`
+	BSS []string `json:"bss" yaml:"bss"`
+}
+
+type HardwareChanges struct {
+	Added    []Hardware `json:"added" yaml:"added"`
+	Removed  []Hardware `json:"removed" yaml:"removed"`
+	Modified []Hardware `json:"modified" yaml:"modified"`
+}
+
+// Hardware identifies a piece of SLS hardware. The extra properties are left out, as their structure depends on the
+// type of the hardware.
+type Hardware struct {
+	Xname      string `json:"xname" yaml:"xname"`
+	Parent     string `json:"parent" yaml:"parent"`
+	Type       string `json:"type" yaml:"type"`
+	TypeString string `json:"type_string" yaml:"type_string"`
+	Class      string `json:"class" yaml:"class"`
+}
+
+type NetworkChanges struct {
+	Modified              []string               `json:"modified" yaml:"modified"`
+	SubnetsAdded          []Subnet               `json:"subnets_added" yaml:"subnets_added"`
+	ReservationsAdded     []Reservation          `json:"reservations_added" yaml:"reservations_added"`
+	StaticRangeExpansions []StaticRangeExpansion `json:"static_range_expansions" yaml:"static_range_expansions"`
+}
+
+type Subnet struct {
+	Network    string `json:"network" yaml:"network"`
+	Name       string `json:"name" yaml:"name"`
+	CIDR       string `json:"cidr" yaml:"cidr"`
+	VLAN       int16  `json:"vlan" yaml:"vlan"`
+	Gateway    string `json:"gateway" yaml:"gateway"`
+	IPv6Prefix string `json:"ipv6_prefix,omitempty" yaml:"ipv6_prefix,omitempty"`
+}
+
+type Reservation struct {
+	Network     string `json:"network" yaml:"network"`
+	Subnet      string `json:"subnet" yaml:"subnet"`
+	Name        string `json:"name" yaml:"name"`
+	IPAddress   string `json:"ip_address" yaml:"ip_address"`
+	IPv6Address string `json:"ipv6_address,omitempty" yaml:"ipv6_address,omitempty"`
+	Xname       string `json:"xname,omitempty" yaml:"xname,omitempty"`
+}
+
+type StaticRangeExpansion struct {
+	Network           string `json:"network" yaml:"network"`
+	Subnet            string `json:"subnet" yaml:"subnet"`
+	PreviousDHCPStart string `json:"previous_dhcp_start" yaml:"previous_dhcp_start"`
+	DHCPStart         string `json:"dhcp_start" yaml:"dhcp_start"`
+	ExpandedBy        uint32 `json:"expanded_by" yaml:"expanded_by"`
+}
+
+// BSSEntry contains the changes to the boot parameters of a single BSS entry, which is either Global or the xname of a
+// management NCN.
+type BSSEntry struct {
+	Name        string             `json:"name" yaml:"name"`
+	HostRecords *HostRecordChanges `json:"host_records,omitempty" yaml:"host_records,omitempty"`
+	WriteFiles  []WriteFileChange  `json:"write_files,omitempty" yaml:"write_files,omitempty"`
+
+	// Route files of the networks route files are built for, that were kept as they were not built by this tool
+	UnmanagedRouteFiles []string `json:"unmanaged_route_files,omitempty" yaml:"unmanaged_route_files,omitempty"`
+}
+
+type HostRecord struct {
+	IP      string   `json:"ip" yaml:"ip"`
+	Aliases []string `json:"aliases" yaml:"aliases"`
+}
+
+type HostRecordChange struct {
+	Previous HostRecord `json:"previous" yaml:"previous"`
+	Current  HostRecord `json:"current" yaml:"current"`
+}
+
+type HostRecordChanges struct {
+	Added   []HostRecord       `json:"added" yaml:"added"`
+	Removed []HostRecord       `json:"removed" yaml:"removed"`
+	Changed []HostRecordChange `json:"changed" yaml:"changed"`
+}
+
+type WriteFileChange struct {
+	Path   string `json:"path" yaml:"path"`
+	Action string `json:"action" yaml:"action"`
+}
+
+// New will create an empty report. The status starts as succeeded, and is changed when an error is set.
+func New(dryRun bool) *Report {
+	return &Report{
+		SchemaVersion: SchemaVersion,
+		DryRun:        dryRun,
+		Status:        StatusSucceeded,
+		Hardware: HardwareChanges{
+			Added:    []Hardware{},
+			Removed:  []Hardware{},
+			Modified: []Hardware{},
+		},
+		Networks: NetworkChanges{
+			Modified:              []string{},
+			SubnetsAdded:          []Subnet{},
+			ReservationsAdded:     []Reservation{},
+			StaticRangeExpansions: []StaticRangeExpansion{},
+		},
+		BSS: []BSSEntry{},
+		Applied: AppliedChanges{
+			BSS: []string{},
+		},
+	}
+}
+
+func newHardware(hardware sls_common.GenericHardware) Hardware {
+	return Hardware{
+		Xname:      hardware.Xname,
+		Parent:     hardware.Parent,
+		Type:       string(hardware.Type),
+		TypeString: string(hardware.TypeString),
+		Class:      string(hardware.Class),
+	}
+}
+
+func newHostRecord(hostRecord bss.HostRecord) HostRecord {
+	return HostRecord{IP: hostRecord.IP, Aliases: hostRecord.Aliases}
+}
+
+// SetError will mark the run as failed. When the topology engine refused to continue, then the run is marked as
+// refused and the offending hardware is added to the report.
+func (r *Report) SetError(err error) {
+	r.Status = StatusFailed
+	r.Error = err.Error()
+
+	var hardwareRemovedErr *engine.HardwareRemovedError
+	if errors.As(err, &hardwareRemovedErr) {
+		r.Status = StatusRefused
+		for _, hardware := range hardwareRemovedErr.Hardware {
+			r.Hardware.Removed = append(r.Hardware.Removed, newHardware(hardware))
+		}
+	}
+
+	var hardwareDiffersErr *engine.HardwareDiffersError
+	if errors.As(err, &hardwareDiffersErr) {
+		r.Status = StatusRefused
+		for _, pair := range hardwareDiffersErr.Hardware {
+			r.Hardware.Modified = append(r.Hardware.Modified, newHardware(pair.HardwareB))
+		}
+	}
+}
+
+// AddTopologyChanges will add the hardware and network changes determined by the topology engine.
+func (r *Report) AddTopologyChanges(topologyChanges *engine.TopologyChanges) {
+	for _, hardware := range topologyChanges.HardwareAdded {
+		r.Hardware.Added = append(r.Hardware.Added, newHardware(hardware))
+	}
+	for _, hardware := range topologyChanges.HardwareRemoved {
+		r.Hardware.Removed = append(r.Hardware.Removed, newHardware(hardware))
+	}
+
+	for networkName := range topologyChanges.ModifiedNetworks {
+		r.Networks.Modified = append(r.Networks.Modified, networkName)
+	}
+	sort.Strings(r.Networks.Modified)
+
+	for _, subnetChange := range topologyChanges.SubnetsAdded {
+		r.Networks.SubnetsAdded = append(r.Networks.SubnetsAdded, Subnet{
+			Network:    subnetChange.NetworkName,
+			Name:       subnetChange.Subnet.Name,
+			CIDR:       subnetChange.Subnet.CIDR,
+			VLAN:       subnetChange.Subnet.VlanID,
+			Gateway:    subnetChange.Subnet.Gateway.String(),
+			IPv6Prefix: subnetChange.IPv6Prefix,
+		})
+	}
+
+	for _, reservationChange := range topologyChanges.IPReservationsAdded {
+		r.Networks.ReservationsAdded = append(r.Networks.ReservationsAdded, Reservation{
+			Network:     reservationChange.NetworkName,
+			Subnet:      reservationChange.SubnetName,
+			Name:        reservationChange.IPReservation.Name,
+			IPAddress:   reservationChange.IPReservation.IPAddress.String(),
+			IPv6Address: reservationChange.IPv6Address,
+			Xname:       reservationChange.ChangedByXname,
+		})
+	}
+
+	for _, expansion := range topologyChanges.StaticRangeExpansions {
+		r.Networks.StaticRangeExpansions = append(r.Networks.StaticRangeExpansions, StaticRangeExpansion{
+			Network:           expansion.NetworkName,
+			Subnet:            expansion.SubnetName,
+			PreviousDHCPStart: expansion.PreviousDHCPStart,
+			DHCPStart:         expansion.DHCPStart,
+			ExpandedBy:        expansion.ExpandedBy,
+		})
+	}
+}
+
+// bssEntry will find the BSS entry with the given name, or add it if it does not exist yet.
+func (r *Report) bssEntry(name string) *BSSEntry {
+	for i := range r.BSS {
+		if r.BSS[i].Name == name {
+			return &r.BSS[i]
+		}
+	}
+
+	r.BSS = append(r.BSS, BSSEntry{Name: name})
+	return &r.BSS[len(r.BSS)-1]
+}
+
+// AddHostRecordChanges will add the changes to the host records of a BSS entry, if there are any.
+func (r *Report) AddHostRecordChanges(name string, hostRecordsReport bss.HostRecordsReport) {
+	if !hostRecordsReport.HasChanges() {
+		return
+	}
+
+	hostRecordChanges := &HostRecordChanges{
+		Added:   []HostRecord{},
+		Removed: []HostRecord{},
+		Changed: []HostRecordChange{},
+	}
+	for _, hostRecord := range hostRecordsReport.Added {
+		hostRecordChanges.Added = append(hostRecordChanges.Added, newHostRecord(hostRecord))
+	}
+	for _, hostRecord := range hostRecordsReport.Removed {
+		hostRecordChanges.Removed = append(hostRecordChanges.Removed, newHostRecord(hostRecord))
+	}
+	for _, change := range hostRecordsReport.Changed {
+		hostRecordChanges.Changed = append(hostRecordChanges.Changed, HostRecordChange{
+			Previous: newHostRecord(change.Previous),
+			Current:  newHostRecord(change.Current),
+		})
+	}
+
+	r.bssEntry(name).HostRecords = hostRecordChanges
+}
+
+// AddWriteFileChanges will add the changes to the write_files of a BSS entry, if there are any.
+func (r *Report) AddWriteFileChanges(name string, writeFileChanges []bss.WriteFileChange) {
+	if len(writeFileChanges) == 0 {
+		return
+	}
+
+	entry := r.bssEntry(name)
+	for _, change := range writeFileChanges {
+		entry.WriteFiles = append(entry.WriteFiles, WriteFileChange{
+			Path:   change.Path,
+			Action: string(change.Action),
+		})
+	}
+}
+
+// SetSLSApplied will record that the hardware and network changes were written to SLS.
+func (r *Report) SetSLSApplied() {
+	r.Applied.SLS = true
+}
+
+// AddBSSApplied will record that the boot parameters of the given BSS entry were written to BSS.
+func (r *Report) AddBSSApplied(name string) {
+	r.Applied.BSS = append(r.Applied.BSS, name)
+}
+
+// AddUnmanagedRouteFiles will add the route files of a BSS entry that were kept as they were not built by this tool, if
+// there are any.
+func (r *Report) AddUnmanagedRouteFiles(name string, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+
+	entry := r.bssEntry(name)
+	entry.UnmanagedRouteFiles = append(entry.UnmanagedRouteFiles, paths...)
+}
+
+// Write will write out the report in the given format.
+func (r *Report) Write(w io.Writer, format Format) error {
+	var reportRaw []byte
+	var err error
+	switch format {
+	case FormatJSON:
+		reportRaw, err = json.MarshalIndent(r, "", "  ")
+		reportRaw = append(reportRaw, '\n')
+	case FormatYAML:
+		reportRaw, err = yaml.Marshal(r)
+	default:
+		return fmt.Errorf("unsupported output format (%s), expected json or yaml", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	_, err = w.Write(reportRaw)
+	return err
+}
@@ -0,0 +1,166 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/internal/engine"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/bss"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/sls"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReportTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ReportTestSuite) TestParseFormat() {
+	format, err := ParseFormat("yaml")
+	suite.NoError(err)
+	suite.Equal(FormatYAML, format)
+
+	_, err = ParseFormat("xml")
+	suite.EqualError(err, "unsupported output format (xml), expected json or yaml")
+}
+
+func (suite *ReportTestSuite) TestEmptyReport() {
+	var buf bytes.Buffer
+	suite.NoError(New(true).Write(&buf, FormatJSON))
+
+	suite.JSONEq(`{
+		"schema_version": "v1",
+		"dry_run": true,
+		"status": "succeeded",
+		"hardware": {"added": [], "removed": [], "modified": []},
+		"networks": {"modified": [], "subnets_added": [], "reservations_added": [], "static_range_expansions": []},
+		"bss": [],
+		"applied": {"sls": false, "bss": []}
+	}`, buf.String())
+}
+
+func (suite *ReportTestSuite) TestTopologyChanges() {
+	r := New(false)
+	r.AddTopologyChanges(&engine.TopologyChanges{
+		HardwareAdded: []sls_common.GenericHardware{
+			sls_common.NewGenericHardware("x3001m0", sls_common.ClassRiver, nil),
+		},
+		ModifiedNetworks: map[string]sls_common.Network{"NMN_RVR": {}, "HMN_RVR": {}},
+		SubnetsAdded: []engine.SubnetChange{{
+			NetworkName: "HMN_RVR",
+			Subnet:      sls_common.IPV4Subnet{Name: "cabinet_3001", CIDR: "10.107.4.0/22", VlanID: 1514, Gateway: net.ParseIP("10.107.4.1")},
+		}},
+		IPReservationsAdded: []engine.IPReservationChange{{
+			NetworkName:    "CAN",
+			SubnetName:     "bootstrap_dhcp",
+			IPReservation:  sls_common.IPReservation{Name: "uan01", IPAddress: net.ParseIP("10.102.4.10")},
+			ChangedByXname: "x3001c0s13b0n0",
+		}},
+		StaticRangeExpansions: []engine.StaticRangeExpansion{{
+			NetworkName: "CAN", SubnetName: "bootstrap_dhcp", PreviousDHCPStart: "10.102.4.10", DHCPStart: "10.102.4.11", ExpandedBy: 1,
+		}},
+	})
+
+	suite.Equal([]Hardware{{Xname: "x3001m0", Parent: "x3001", Type: "comptype_cab_pdu_controller", TypeString: "CabinetPDUController", Class: "River"}}, r.Hardware.Added)
+	suite.Equal([]string{"HMN_RVR", "NMN_RVR"}, r.Networks.Modified)
+	suite.Equal([]Subnet{{Network: "HMN_RVR", Name: "cabinet_3001", CIDR: "10.107.4.0/22", VLAN: 1514, Gateway: "10.107.4.1"}}, r.Networks.SubnetsAdded)
+	suite.Equal([]Reservation{{Network: "CAN", Subnet: "bootstrap_dhcp", Name: "uan01", IPAddress: "10.102.4.10", Xname: "x3001c0s13b0n0"}}, r.Networks.ReservationsAdded)
+	suite.Equal([]StaticRangeExpansion{{Network: "CAN", Subnet: "bootstrap_dhcp", PreviousDHCPStart: "10.102.4.10", DHCPStart: "10.102.4.11", ExpandedBy: 1}}, r.Networks.StaticRangeExpansions)
+}
+
+func (suite *ReportTestSuite) TestBSSChanges() {
+	r := New(false)
+	r.AddHostRecordChanges("Global", bss.HostRecordsReport{})
+	r.AddWriteFileChanges("x3000c0s1b0n0", nil)
+	r.AddUnmanagedRouteFiles("x3000c0s1b0n0", nil)
+	suite.Empty(r.BSS)
+
+	r.AddHostRecordChanges("Global", bss.HostRecordsReport{
+		Added: []bss.HostRecord{{IP: "10.254.0.5", Aliases: []string{"sw-leaf-bmc-002"}}},
+	})
+	r.AddWriteFileChanges("x3000c0s1b0n0", []bss.WriteFileChange{
+		{Path: "/etc/sysconfig/network/ifroute-bond0.hmn0", Action: bss.WriteFileModified},
+	})
+	r.AddUnmanagedRouteFiles("x3000c0s1b0n0", []string{"/etc/sysconfig/network/ifroute-bond0.nmn1"})
+
+	suite.Equal([]BSSEntry{
+		{
+			Name: "Global",
+			HostRecords: &HostRecordChanges{
+				Added:   []HostRecord{{IP: "10.254.0.5", Aliases: []string{"sw-leaf-bmc-002"}}},
+				Removed: []HostRecord{},
+				Changed: []HostRecordChange{},
+			},
+		},
+		{
+			Name:                "x3000c0s1b0n0",
+			WriteFiles:          []WriteFileChange{{Path: "/etc/sysconfig/network/ifroute-bond0.hmn0", Action: "modified"}},
+			UnmanagedRouteFiles: []string{"/etc/sysconfig/network/ifroute-bond0.nmn1"},
+		},
+	}, r.BSS)
+}
+
+func (suite *ReportTestSuite) TestRefused() {
+	r := New(false)
+	r.SetError(&engine.HardwareDiffersError{Hardware: []sls.GenericHardwarePair{{
+		Xname:     "x3000m0",
+		HardwareA: sls_common.NewGenericHardware("x3000m0", sls_common.ClassRiver, nil),
+		HardwareB: sls_common.NewGenericHardware("x3000m0", sls_common.ClassMountain, nil),
+	}}})
+
+	suite.Equal(StatusRefused, r.Status)
+	suite.Equal("refusing to continue, found hardware with differing values (Class and/or ExtraProperties). Please reconcile the differences", r.Error)
+	suite.Equal([]Hardware{{Xname: "x3000m0", Parent: "x3000", Type: "comptype_cab_pdu_controller", TypeString: "CabinetPDUController", Class: "Mountain"}}, r.Hardware.Modified)
+}
+
+func (suite *ReportTestSuite) TestAppliedBeforeFailure() {
+	r := New(false)
+	r.SetSLSApplied()
+	r.AddBSSApplied("Global")
+	r.SetError(errors.New("failed to put bootparameters for x3000c0s1b0n0"))
+
+	var buf bytes.Buffer
+	suite.NoError(r.Write(&buf, FormatJSON))
+
+	var written Report
+	suite.NoError(json.Unmarshal(buf.Bytes(), &written))
+	suite.Equal(StatusFailed, written.Status)
+	suite.Equal("failed to put bootparameters for x3000c0s1b0n0", written.Error)
+	suite.Equal(AppliedChanges{SLS: true, BSS: []string{"Global"}}, written.Applied)
+}
+
+func (suite *ReportTestSuite) TestYAML() {
+	var buf bytes.Buffer
+	suite.NoError(New(false).Write(&buf, FormatYAML))
+	suite.Contains(buf.String(), "schema_version: v1\n")
+	suite.Contains(buf.String(), "hardware:\n  added: []\n")
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
@@ -0,0 +1,127 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package bss
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/sls"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+)
+
+// BICANMode is the customer network used as the system default route. On bifurcated CAN (BICAN) systems user traffic
+// can be moved from the CAN to the CHN, which runs over the high speed network.
+type BICANMode string
+
+const (
+	BICANModeCAN BICANMode = "CAN"
+	BICANModeCHN BICANMode = "CHN"
+)
+
+// CHNParentDevice is the device the CHN is configured on within management NCNs, as the CHN runs over the high speed
+// network instead of the management network.
+const CHNParentDevice = "hsn0"
+
+// DetermineBICANMode will determine which customer network is the system default route from the SystemDefaultRoute of
+// the BICAN network in SLS. Systems without a BICAN network predate the CHN, and use the CAN if it is present.
+func DetermineBICANMode(networks sls_common.NetworkArray) (BICANMode, error) {
+	present := map[string]bool{}
+	var bicanNetwork *sls_common.Network
+	for i, network := range networks {
+		name := strings.ToUpper(network.Name)
+		present[name] = true
+
+		if name == "BICAN" {
+			bicanNetwork = &networks[i]
+		}
+	}
+
+	if bicanNetwork != nil {
+		var extraProperties sls_common.NetworkExtraProperties
+		if err := sls.DecodeNetworkExtraProperties(bicanNetwork.ExtraPropertiesRaw, &extraProperties); err != nil {
+			return "", fmt.Errorf("failed to decode extra properties for network (%s): %w", bicanNetwork.Name, err)
+		}
+
+		switch mode := BICANMode(strings.ToUpper(extraProperties.SystemDefaultRoute)); mode {
+		case BICANModeCAN, BICANModeCHN:
+			if !present[string(mode)] {
+				return "", fmt.Errorf("BICAN network has SystemDefaultRoute (%s), but the %s network does not exist in SLS", extraProperties.SystemDefaultRoute, mode)
+			}
+
+			return mode, nil
+		case "":
+			// Fall back to which customer networks are present
+		default:
+			return "", fmt.Errorf("BICAN network has unexpected SystemDefaultRoute (%s), expected CAN or CHN", extraProperties.SystemDefaultRoute)
+		}
+	}
+
+	if present[string(BICANModeCAN)] {
+		return BICANModeCAN, nil
+	}
+	if present[string(BICANModeCHN)] {
+		return BICANModeCHN, nil
+	}
+
+	return "", fmt.Errorf("SLS must have either CAN or CHN defined")
+}
+
+// CustomerIPAMNetworks will determine the BICAN mode of the system, and the lower case names of the customer networks
+// present in SLS that management NCNs need IPAM for, in addition to the IPAMNetworks.
+func CustomerIPAMNetworks(networks sls_common.NetworkArray) (BICANMode, []string, error) {
+	mode, err := DetermineBICANMode(networks)
+	if err != nil {
+		return "", nil, err
+	}
+
+	present := map[string]bool{}
+	for _, network := range networks {
+		present[strings.ToUpper(network.Name)] = true
+	}
+
+	var customerNetworks []string
+	for _, mode := range []BICANMode{BICANModeCAN, BICANModeCHN} {
+		if present[string(mode)] {
+			customerNetworks = append(customerNetworks, strings.ToLower(string(mode)))
+		}
+	}
+
+	return mode, customerNetworks, nil
+}
+
+// ApplyBICANMode will remove the gateway from the IPAM of the customer network that is not the system default route,
+// so the management NCN only has a default route through the CAN or the CHN.
+func ApplyBICANMode(ipamNetworks CloudInitIPAM, mode BICANMode) {
+	for _, customerNetwork := range []BICANMode{BICANModeCAN, BICANModeCHN} {
+		if customerNetwork == mode {
+			continue
+		}
+
+		network := strings.ToLower(string(customerNetwork))
+		if ipamNetwork, ok := ipamNetworks[network]; ok {
+			ipamNetwork.Gateway = ""
+			ipamNetworks[network] = ipamNetwork
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package bss
+
+import (
+	"net"
+	"testing"
+
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+)
+
+type BICANTestSuite struct {
+	suite.Suite
+}
+
+func (suite *BICANTestSuite) bicanNetwork(systemDefaultRoute string) sls_common.Network {
+	return sls_common.Network{
+		Name: "BICAN",
+		ExtraPropertiesRaw: map[string]interface{}{
+			"CIDR":               "0.0.0.0/0",
+			"SystemDefaultRoute": systemDefaultRoute,
+		},
+	}
+}
+
+func (suite *BICANTestSuite) TestSystemDefaultRoute() {
+	networks := sls_common.NetworkArray{{Name: "CAN"}, {Name: "CHN"}, suite.bicanNetwork("CHN")}
+
+	mode, err := DetermineBICANMode(networks)
+	suite.NoError(err)
+	suite.Equal(BICANModeCHN, mode)
+
+	mode, customerNetworks, err := CustomerIPAMNetworks(networks)
+	suite.NoError(err)
+	suite.Equal(BICANModeCHN, mode)
+	suite.Equal([]string{"can", "chn"}, customerNetworks)
+}
+
+func (suite *BICANTestSuite) TestWithoutBICANNetwork() {
+	mode, err := DetermineBICANMode(sls_common.NetworkArray{{Name: "CAN"}})
+	suite.NoError(err)
+	suite.Equal(BICANModeCAN, mode)
+
+	mode, err = DetermineBICANMode(sls_common.NetworkArray{{Name: "CHN"}})
+	suite.NoError(err)
+	suite.Equal(BICANModeCHN, mode)
+}
+
+func (suite *BICANTestSuite) TestMissingNetwork() {
+	_, err := DetermineBICANMode(sls_common.NetworkArray{{Name: "CAN"}, suite.bicanNetwork("CHN")})
+	suite.EqualError(err, "BICAN network has SystemDefaultRoute (CHN), but the CHN network does not exist in SLS")
+}
+
+func (suite *BICANTestSuite) TestUnexpectedSystemDefaultRoute() {
+	_, err := DetermineBICANMode(sls_common.NetworkArray{{Name: "CAN"}, suite.bicanNetwork("CMN")})
+	suite.EqualError(err, "BICAN network has unexpected SystemDefaultRoute (CMN), expected CAN or CHN")
+}
+
+func (suite *BICANTestSuite) TestNoCustomerNetworks() {
+	_, _, err := CustomerIPAMNetworks(sls_common.NetworkArray{{Name: "NMN"}})
+	suite.EqualError(err, "SLS must have either CAN or CHN defined")
+}
+
+func (suite *BICANTestSuite) TestCHNIPAM() {
+	chnNetworks := append(sls_common.NetworkArray{}, networks...)
+	chnNetworks[0] = sls_common.Network{
+		Name: "CHN",
+		ExtraPropertiesRaw: map[string]interface{}{
+			"CIDR": "10.103.9.0/25",
+			"Subnets": []sls_common.IPV4Subnet{
+				{
+					Name:    "bootstrap_dhcp",
+					CIDR:    "10.103.9.0/26",
+					VlanID:  5,
+					Gateway: net.IPv4(10, 103, 9, 1),
+					IPReservations: []sls_common.IPReservation{
+						{Name: "ncn-w001", Comment: "x3700c0s2b0n0", IPAddress: net.IPv4(10, 103, 9, 10)},
+					},
+				},
+			},
+		},
+	}
+
+	// Worker with a CHN reservation
+	ipamNetworks, err := GetIPAMForNCN(ncns[1], chnNetworks, DefaultNCNInterfaces, "chn")
+	suite.NoError(err)
+	suite.Equal(IPAMNetwork{
+		Gateway:      "10.103.9.1",
+		CIDR:         "10.103.9.10/25",
+		ParentDevice: "hsn0",
+		VlanID:       5,
+	}, ipamNetworks["chn"])
+	suite.Equal("bond0", ipamNetworks["nmn"].ParentDevice)
+
+	// Master without a CHN reservation
+	ipamNetworks, err = GetIPAMForNCN(ncns[0], chnNetworks, DefaultNCNInterfaces, "chn")
+	suite.NoError(err)
+	suite.NotContains(ipamNetworks, "chn")
+}
+
+func (suite *BICANTestSuite) ipamNetworks() CloudInitIPAM {
+	return CloudInitIPAM{
+		"can": {Gateway: "10.102.4.1", CIDR: "10.102.4.10/24", ParentDevice: "bond0", VlanID: 6},
+		"chn": {Gateway: "10.103.9.1", CIDR: "10.103.9.10/25", ParentDevice: "hsn0", VlanID: 5},
+		"nmn": {Gateway: "10.252.0.1", CIDR: "10.252.1.10/17", ParentDevice: "bond0", VlanID: 2},
+	}
+}
+
+func (suite *BICANTestSuite) TestApplyBICANModeCHN() {
+	ipamNetworks := suite.ipamNetworks()
+	ApplyBICANMode(ipamNetworks, BICANModeCHN)
+
+	suite.Equal("", ipamNetworks["can"].Gateway)
+	suite.Equal("10.102.4.10/24", ipamNetworks["can"].CIDR)
+	suite.Equal("10.103.9.1", ipamNetworks["chn"].Gateway)
+	suite.Equal("10.252.0.1", ipamNetworks["nmn"].Gateway)
+}
+
+func (suite *BICANTestSuite) TestApplyBICANModeCAN() {
+	ipamNetworks := suite.ipamNetworks()
+	ApplyBICANMode(ipamNetworks, BICANModeCAN)
+
+	suite.Equal("10.102.4.1", ipamNetworks["can"].Gateway)
+	suite.Equal("", ipamNetworks["chn"].Gateway)
+}
+
+func (suite *BICANTestSuite) TestApplyBICANModeWithoutCHN() {
+	ipamNetworks := suite.ipamNetworks()
+	delete(ipamNetworks, "chn")
+	ApplyBICANMode(ipamNetworks, BICANModeCAN)
+
+	suite.NotContains(ipamNetworks, "chn")
+	suite.Equal("10.102.4.1", ipamNetworks["can"].Gateway)
+}
+
+func TestBICANTestSuite(t *testing.T) {
+	suite.Run(t, new(BICANTestSuite))
+}
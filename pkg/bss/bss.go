@@ -24,35 +24,29 @@ package bss
 
 import (
 	"fmt"
-	"log"
 	"net"
 	"sort"
 	"strings"
 
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
 	"github.com/Cray-HPE/hardware-topology-assistant/pkg/sls"
 	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
 	"github.com/Cray-HPE/hms-xname/xnametypes"
 	"github.com/mitchellh/mapstructure"
 )
 
-//
-// TODO look into making this package return an error
-//
-
 // This following code was taken from CSI
 // https://github.com/Cray-HPE/cray-site-init/blob/main/cmd/upgrade-metadata.go#L294-L422
 
+// GetIPAMForNCN will build the cloud-init IPAM metadata of a management NCN from its IP reservations in SLS. Errors
+// are returned as a *NetworkError that contains the xname of the NCN and the network at fault.
 func GetIPAMForNCN(managementNCN sls_common.GenericHardware,
-	networks sls_common.NetworkArray, extraSLSNetworks ...string) (ipamNetworks CloudInitIPAM) {
-	ipamNetworks = make(CloudInitIPAM)
+	networks sls_common.NetworkArray, interfaces NCNInterfaces, extraSLSNetworks ...string) (CloudInitIPAM, error) {
+	ipamNetworks := make(CloudInitIPAM)
 
 	// For each of the required networks, go build an IPAMNetwork object and add that to the ipamNetworks
 	// above.
 	for _, ipamNetwork := range append(IPAMNetworks[:], extraSLSNetworks...) {
-		// the chn causes issues with the IPAM so skip it
-		if ipamNetwork == "chn" {
-			continue
-		}
 		// Search SLS networks for this network.
 		var targetSLSNetwork *sls_common.Network
 		for _, slsNetwork := range networks {
@@ -63,14 +57,15 @@ func GetIPAMForNCN(managementNCN sls_common.GenericHardware,
 		}
 
 		if targetSLSNetwork == nil {
-			log.Fatalf("Failed to find required IPAM network [%s] in SLS networks!", ipamNetwork)
+			return nil, &NetworkError{Xname: managementNCN.Xname, Network: ipamNetwork, Err: ErrNetworkNotFound}
 		}
 
 		// Map this network to a usable structure.
 		var networkExtraProperties sls_common.NetworkExtraProperties
 		err := sls.DecodeNetworkExtraProperties(targetSLSNetwork.ExtraPropertiesRaw, &networkExtraProperties)
 		if err != nil {
-			log.Fatalf("Failed to decode raw network extra properties to correct structure: %s", err)
+			return nil, &NetworkError{Xname: managementNCN.Xname, Network: targetSLSNetwork.Name,
+				Err: fmt.Errorf("failed to decode network extra properties: %w", err)}
 		}
 
 		// The target SLS network is determined, now we need the right reservation.
@@ -79,7 +74,8 @@ func GetIPAMForNCN(managementNCN sls_common.GenericHardware,
 
 		_, targetNet, err := net.ParseCIDR(networkExtraProperties.CIDR)
 		if err != nil {
-			log.Fatalf("Failed to parse SLS network CIDR (%s): %s", networkExtraProperties.CIDR, err)
+			return nil, &NetworkError{Xname: managementNCN.Xname, Network: targetSLSNetwork.Name,
+				Err: fmt.Errorf("failed to parse network CIDR (%s): %w", networkExtraProperties.CIDR, err)}
 		}
 
 		for _, subnet := range networkExtraProperties.Subnets {
@@ -98,8 +94,13 @@ func GetIPAMForNCN(managementNCN sls_common.GenericHardware,
 		}
 
 		if targetSubnet == nil || targetReservation == nil {
-			log.Fatalf("Failed to find subnet/reservation for this managment NCN xname (%s)!",
-				managementNCN.Xname)
+			if ipamNetwork == "chn" {
+				// Not every management NCN is connected to the high speed network, so only the NCNs with an IP
+				// reservation in the CHN get IPAM for it.
+				continue
+			}
+
+			return nil, &NetworkError{Xname: managementNCN.Xname, Network: targetSLSNetwork.Name, Err: ErrReservationNotFound}
 		}
 
 		// Finally, we have all the pieces, wrangle the data! Speaking of, here's an example of what this
@@ -117,11 +118,12 @@ func GetIPAMForNCN(managementNCN sls_common.GenericHardware,
 
 		_, ipv4Net, err := net.ParseCIDR(targetSubnet.CIDR)
 		if err != nil {
-			log.Fatalf("Failed to parse SLS network CIDR (%s): %s", targetSubnet.CIDR, err)
+			return nil, &NetworkError{Xname: managementNCN.Xname, Network: targetSLSNetwork.Name, Subnet: targetSubnet.Name,
+				Err: fmt.Errorf("failed to parse subnet CIDR (%s): %w", targetSubnet.CIDR, err)}
 		}
 
 		var maskBits int
-		if ipamNetwork == "cmn" || ipamNetwork == "can" {
+		if ipamNetwork == "cmn" || ipamNetwork == "can" || ipamNetwork == "chn" {
 			maskBits, _ = targetNet.Mask.Size()
 		} else {
 			maskBits, _ = ipv4Net.Mask.Size()
@@ -131,22 +133,30 @@ func GetIPAMForNCN(managementNCN sls_common.GenericHardware,
 		thisIPAMNetwork := IPAMNetwork{
 			Gateway:      targetSubnet.Gateway.String(),
 			CIDR:         fmt.Sprintf("%s/%d", targetReservation.IPAddress, maskBits),
-			ParentDevice: "bond0", // FIXME: Remove bond0 hardcode.
+			ParentDevice: interfaces.ParentDevice(ipamNetwork),
 			VlanID:       targetSubnet.VlanID,
 		}
 		ipamNetworks[ipamNetwork] = thisIPAMNetwork
 	}
 
-	return
+	return ipamNetworks, nil
 }
 
-func GetWriteFiles(networks sls_common.NetworkArray, ipamNetworks CloudInitIPAM) (writeFiles []WriteFile) {
+// RouteFileNetworks are the lower case names of the networks that route files are built for.
+var RouteFileNetworks = []string{"nmn", "hmn"}
+
+// RouteFilePathPrefix is the path of a route file without the name of its VLAN interface.
+const RouteFilePathPrefix = "/etc/sysconfig/network/ifroute-"
+
+// GetWriteFiles will build the route files of a management NCN for the NMN and HMN networks. Errors are returned as a
+// *NetworkError that contains the network and subnet at fault.
+func GetWriteFiles(networks sls_common.NetworkArray, ipamNetworks CloudInitIPAM, interfaces NCNInterfaces) ([]WriteFile, error) {
 	// In the case of 1.0 -> 1.2 we need to add route files for a few of the networks.
 	// The process is simple, get the CIDR and gateway for those networks and then format them as an ifroute file.
 	// Here's an example:
 	routeFiles := make(map[string][]string)
 
-	for _, neededNetwork := range []string{"nmn", "hmn"} {
+	for _, neededNetwork := range RouteFileNetworks {
 		ipamNetwork := ipamNetworks[neededNetwork]
 
 		for _, network := range networks {
@@ -160,7 +170,7 @@ func GetWriteFiles(networks sls_common.NetworkArray, ipamNetworks CloudInitIPAM)
 				var networkExtraProperties sls_common.NetworkExtraProperties
 				err := sls.DecodeNetworkExtraProperties(network.ExtraPropertiesRaw, &networkExtraProperties)
 				if err != nil {
-					log.Fatalf("Failed to decode raw network extra properties to correct structure: %s", err)
+					return nil, &NetworkError{Network: network.Name, Err: fmt.Errorf("failed to decode network extra properties: %w", err)}
 				}
 
 				thisRouteFile := routeFiles[neededNetwork]
@@ -169,7 +179,8 @@ func GetWriteFiles(networks sls_common.NetworkArray, ipamNetworks CloudInitIPAM)
 				for _, subnet := range networkExtraProperties.Subnets {
 					_, ipv4Net, err := net.ParseCIDR(subnet.CIDR)
 					if err != nil {
-						log.Fatalf("Failed to parse SLS network CIDR (%s): %s", subnet.CIDR, err)
+						return nil, &NetworkError{Network: network.Name, Subnet: subnet.Name,
+							Err: fmt.Errorf("failed to parse subnet CIDR (%s): %w", subnet.CIDR, err)}
 					}
 
 					// Ignore NMN UAI subnet
@@ -183,8 +194,8 @@ func GetWriteFiles(networks sls_common.NetworkArray, ipamNetworks CloudInitIPAM)
 						continue
 					}
 
-					route := fmt.Sprintf("%s %s - %s.%s0",
-						ipv4Net.String(), gatewayIP.String(), "bond0", neededNetwork)
+					route := fmt.Sprintf("%s %s - %s",
+						ipv4Net.String(), gatewayIP.String(), interfaces.VLANInterface(neededNetwork))
 
 					// Don't add the route if we already have it
 					found := false
@@ -216,40 +227,62 @@ func GetWriteFiles(networks sls_common.NetworkArray, ipamNetworks CloudInitIPAM)
 	sort.Sort(sort.Reverse(sort.StringSlice(networkNames)))
 
 	// We now have all the write files, let's make objects for them.
+	var writeFiles []WriteFile
 	for _, networkName := range networkNames {
 		routeFile := routeFiles[networkName]
 
 		writeFile := WriteFile{
 			Content:     strings.Join(routeFile, "\n"),
 			Owner:       "root:root",
-			Path:        fmt.Sprintf("/etc/sysconfig/network/ifroute-bond0.%s0", networkName),
+			Path:        RouteFilePathPrefix + interfaces.VLANInterface(networkName),
 			Permissions: "0644",
 		}
 		writeFiles = append(writeFiles, writeFile)
 	}
 
-	return
+	return writeFiles, nil
 }
 
-// buildBSSHostRecord will build a BSS HostRecord
-func BuildBSSHostRecord(networkEPs map[string]*sls_common.NetworkExtraProperties, networkName, subnetName, reservationName string, aliases []string) HostRecord {
-	subnet, _, err := networkEPs[networkName].LookupSubnet(subnetName)
+// BuildBSSHostRecord will build a BSS HostRecord for the given IP reservation. Errors are returned as a *NetworkError
+// with the name of the IP reservation as the xname.
+func BuildBSSHostRecord(networkEPs map[string]*sls_common.NetworkExtraProperties, networkName, subnetName, reservationName string, aliases []string) (HostRecord, error) {
+	networkEP, ok := networkEPs[networkName]
+	if !ok {
+		return HostRecord{}, &NetworkError{Xname: reservationName, Network: networkName, Err: ErrNetworkNotFound}
+	}
+
+	subnet, _, err := networkEP.LookupSubnet(subnetName)
 	if err != nil {
-		log.Fatalf("Unable to find %s in the %s network", subnetName, networkName)
+		return HostRecord{}, &NetworkError{Xname: reservationName, Network: networkName, Subnet: subnetName, Err: ErrSubnetNotFound}
 	}
 	ipReservation, found := subnet.ReservationsByName()[reservationName]
 	if !found {
-		log.Fatalf("Failed to find IP reservation for %s in the %s %s subnet", reservationName, networkName, subnetName)
+		return HostRecord{}, &NetworkError{Xname: reservationName, Network: networkName, Subnet: subnetName, Err: ErrReservationNotFound}
 	}
 
 	return HostRecord{
 		IP:      ipReservation.IPAddress.String(),
 		Aliases: aliases,
+	}, nil
+}
+
+// FindHostRecord will find the first host record with the given alias.
+func FindHostRecord(hostRecords HostRecords, alias string) (HostRecord, bool) {
+	for _, hostRecord := range hostRecords {
+		for _, hostRecordAlias := range hostRecord.Aliases {
+			if hostRecordAlias == alias {
+				return hostRecord, true
+			}
+		}
 	}
+
+	return HostRecord{}, false
 }
 
-// getBSSGlobalHostRecords is the BSS analog of the pit.MakeBasecampHostRecords that works with SLS data
-func GetBSSGlobalHostRecords(managementNCNs []sls_common.GenericHardware, networks sls_common.NetworkArray) HostRecords {
+// GetBSSGlobalHostRecords is the BSS analog of the pit.MakeBasecampHostRecords that works with SLS data. The well-known
+// host records are built from their IP reservations in SLS, and the pit host record, if given, is included as is. All
+// well-known host records with a missing IP reservation are reported in the returned error.
+func GetBSSGlobalHostRecords(managementNCNs []sls_common.GenericHardware, networks sls_common.NetworkArray, wellKnownHostRecords configs.WellKnownHostRecords, pitHostRecord *HostRecord) (HostRecords, error) {
 
 	// Collase all of the Network ExtraProperties into single map for lookups
 	networkEPs := map[string]*sls_common.NetworkExtraProperties{}
@@ -258,49 +291,46 @@ func GetBSSGlobalHostRecords(managementNCNs []sls_common.GenericHardware, networ
 		var networkExtraProperties sls_common.NetworkExtraProperties
 		err := sls.DecodeNetworkExtraProperties(network.ExtraPropertiesRaw, &networkExtraProperties)
 		if err != nil {
-			log.Fatalf("Failed to decode raw network extra properties to correct structure: %s", err)
+			return nil, &NetworkError{Network: network.Name, Err: fmt.Errorf("failed to decode network extra properties: %w", err)}
 		}
 
 		networkEPs[network.Name] = &networkExtraProperties
 	}
 
 	var globalHostRecords HostRecords
+	var missingHostRecords []error
 
 	// Add the NCN Interfaces.
 	for _, managementNCN := range managementNCNs {
 		var ncnExtraProperties sls_common.ComptypeNode
 		err := mapstructure.Decode(managementNCN.ExtraPropertiesRaw, &ncnExtraProperties)
 		if err != nil {
-			log.Fatalf("Failed to decode raw NCN extra properties to correct structure: %s", err)
+			return nil, fmt.Errorf("failed to decode extra properties of management NCN (%s): %w", managementNCN.Xname, err)
 		}
 
 		if len(ncnExtraProperties.Aliases) == 0 {
-			log.Fatalf("NCN has no aliases defined in SLS: %+v", managementNCN)
+			return nil, fmt.Errorf("management NCN (%s) has no aliases defined in SLS", managementNCN.Xname)
 		}
 
 		ncnAlias := ncnExtraProperties.Aliases[0]
 
 		// Add the NCN interface host records.
-		var ipamNetworks CloudInitIPAM
-		extraNets := []string{}
-
-		if _, ok := networkEPs["CHN"]; ok {
-			extraNets = append(extraNets, "chn")
-		}
-		if _, ok := networkEPs["CAN"]; ok {
-			extraNets = append(extraNets, "can")
+		_, extraNets, err := CustomerIPAMNetworks(networks)
+		if err != nil {
+			return nil, err
 		}
-
-		if len(extraNets) == 0 {
-			log.Fatalf("SLS must have either CAN or CHN defined")
+		// Only the IPs of the NCN are used, so the interface naming does not matter
+		ipamNetworks, err := GetIPAMForNCN(managementNCN, networks, DefaultNCNInterfaces, extraNets...)
+		if err != nil {
+			return nil, err
 		}
-		ipamNetworks = GetIPAMForNCN(managementNCN, networks, extraNets...)
 
 		for network, ipam := range ipamNetworks {
 			// Get the IP of the NCN for this network.
 			ip, _, err := net.ParseCIDR(ipam.CIDR)
 			if err != nil {
-				log.Fatalf("Failed to parse BSS IPAM Network CIDR (%s): %s", ipam.CIDR, err)
+				return nil, &NetworkError{Xname: managementNCN.Xname, Network: network,
+					Err: fmt.Errorf("failed to parse IPAM CIDR (%s): %w", ipam.CIDR, err)}
 			}
 
 			hostRecord := HostRecord{
@@ -317,36 +347,41 @@ func GetBSSGlobalHostRecords(managementNCNs []sls_common.GenericHardware, networ
 
 		// Next add the NCN BMC host record
 		bmcXname := xnametypes.GetHMSCompParent(managementNCN.Xname)
-		globalHostRecords = append(globalHostRecords,
-			BuildBSSHostRecord(networkEPs, "HMN", "bootstrap_dhcp", bmcXname, []string{fmt.Sprintf("%s-mgmt", ncnAlias)}),
-		)
+		bmcHostRecord, err := BuildBSSHostRecord(networkEPs, "HMN", "bootstrap_dhcp", bmcXname, []string{fmt.Sprintf("%s-mgmt", ncnAlias)})
+		if err != nil {
+			missingHostRecords = append(missingHostRecords, err)
+		} else {
+			globalHostRecords = append(globalHostRecords, bmcHostRecord)
+		}
 	}
 
-	// Add kubeapi-vip
-	globalHostRecords = append(globalHostRecords,
-		BuildBSSHostRecord(networkEPs, "NMN", "bootstrap_dhcp", "kubeapi-vip", []string{"kubeapi-vip", "kubeapi-vip.nmn"}),
-	)
-
-	// Add rgw-vip
-	globalHostRecords = append(globalHostRecords,
-		BuildBSSHostRecord(networkEPs, "NMN", "bootstrap_dhcp", "rgw-vip", []string{"rgw-vip", "rgw-vip.nmn"}),
-	)
+	// Add the well-known host records, such as VIPs
+	for _, wellKnownHostRecord := range wellKnownHostRecords {
+		hostRecord, err := BuildBSSHostRecord(networkEPs, wellKnownHostRecord.Network, wellKnownHostRecord.Subnet, wellKnownHostRecord.Reservation, wellKnownHostRecord.Aliases)
+		if err != nil {
+			missingHostRecords = append(missingHostRecords, err)
+			continue
+		}
+		globalHostRecords = append(globalHostRecords, hostRecord)
+	}
 
-	// Using the original InstallNCN as the host for pit.nmn
-	// HACK, I'm assuming ncn-m001
-	globalHostRecords = append(globalHostRecords,
-		BuildBSSHostRecord(networkEPs, "NMN", "bootstrap_dhcp", "ncn-m001", []string{"pit", "pit.nmn"}),
-	)
+	// The pit host record points at the node the system was installed from, which is not recorded in SLS.
+	if pitHostRecord != nil {
+		globalHostRecords = append(globalHostRecords, *pitHostRecord)
+	}
 
-	// Add in packages.local and registry.local pointing toward the API Gateway
-	globalHostRecords = append(globalHostRecords,
-		BuildBSSHostRecord(networkEPs, "NMNLB", "nmn_metallb_address_pool", "istio-ingressgateway", []string{"packages.local", "registry.local"}),
-	)
+	if len(missingHostRecords) != 0 {
+		return nil, &HostRecordsError{Errors: missingHostRecords}
+	}
 
 	// Add entries for switches
-	hmnNetSubnet, _, err := networkEPs["HMN"].LookupSubnet("network_hardware")
+	hmnNetwork, ok := networkEPs["HMN"]
+	if !ok {
+		return nil, &NetworkError{Network: "HMN", Err: ErrNetworkNotFound}
+	}
+	hmnNetSubnet, _, err := hmnNetwork.LookupSubnet("network_hardware")
 	if err != nil {
-		log.Fatal("Unable to find network_hardware in the HMN network")
+		return nil, &NetworkError{Network: "HMN", Subnet: "network_hardware", Err: ErrSubnetNotFound}
 	}
 
 	for _, ipReservation := range hmnNetSubnet.IPReservations {
@@ -363,5 +398,5 @@ func GetBSSGlobalHostRecords(managementNCNs []sls_common.GenericHardware, networ
 		return globalHostRecords[i].IP < globalHostRecords[j].IP
 	})
 
-	return globalHostRecords
+	return globalHostRecords, nil
 }
@@ -1,6 +1,7 @@
 package bss
 
 import (
+	"errors"
 	"net"
 	"testing"
 
@@ -171,7 +172,10 @@ func TestGetIPAMForNCN(t *testing.T) {
 		for _, ncn := range ncns { // check various types of ncns
 			t.Run(tt.name, func(t *testing.T) {
 				// run the function
-				ipamNetworks := GetIPAMForNCN(ncn, networks, tt.extraSLSNetworks...)
+				ipamNetworks, err := GetIPAMForNCN(ncn, networks, DefaultNCNInterfaces, tt.extraSLSNetworks...)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
 
 				// fail if there are not enough reservations
 				if len(ipamNetworks) != len(tt.expectedNetworks) {
@@ -188,3 +192,18 @@ func TestGetIPAMForNCN(t *testing.T) {
 		}
 	}
 }
+
+func TestGetIPAMForNCN_MissingNetwork(t *testing.T) {
+	_, err := GetIPAMForNCN(ncns[0], networks, DefaultNCNInterfaces, "can")
+	if err == nil {
+		t.Fatalf("expected an error for the missing CAN network")
+	}
+
+	var networkErr *NetworkError
+	if !errors.As(err, &networkErr) {
+		t.Fatalf("expected a NetworkError, got %T", err)
+	}
+	if networkErr.Xname != ncns[0].Xname || networkErr.Network != "can" || !errors.Is(err, ErrNetworkNotFound) {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -24,11 +24,16 @@ package bss
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/Cray-HPE/hms-bss/pkg/bssTypes"
 )
@@ -40,6 +45,46 @@ type BSSClient struct {
 	token      string
 }
 
+// ProblemDetails - RFC 7807 problem details returned by BSS when a request fails.
+type ProblemDetails struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// ResponseError - Error returned when BSS responds with an unexpected HTTP status. If BSS provided RFC 7807 problem
+// details, then they are parsed into Problem, otherwise the raw response body is kept in Body.
+type ResponseError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Problem    *ProblemDetails
+	Body       string
+}
+
+func (e *ResponseError) Error() string {
+	message := e.Body
+	if e.Problem != nil {
+		message = e.Problem.Title
+		if e.Problem.Detail != "" {
+			message = fmt.Sprintf("%s: %s", e.Problem.Title, e.Problem.Detail)
+		}
+	}
+
+	return fmt.Sprintf("BSS %s %s failed with status %d: %s", e.Method, e.URL, e.StatusCode, message)
+}
+
+// NotFoundError - Error returned when BSS does not have boot parameters for some of the requested names.
+type NotFoundError struct {
+	Names []string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("BSS boot parameters not found for %s", strings.Join(e.Names, ", "))
+}
+
 // NewBSSClient - Creates a new BSS client.
 func NewBSSClient(baseURL string, httpClient *http.Client, token string) *BSSClient {
 	if httpClient == nil {
@@ -57,32 +102,61 @@ func NewBSSClient(baseURL string, httpClient *http.Client, token string) *BSSCli
 	}
 }
 
-// UploadEntryToBSS - Uploads an entry to BSS.
-func (utilsClient *BSSClient) UploadEntryToBSS(bssEntry bssTypes.BootParams, method string) (string, error) {
-	url := fmt.Sprintf("%s/boot/v1/bootparameters", utilsClient.baseURL)
+// do - Performs a request against BSS, and returns the response body. A response with a non 2xx status is returned as
+// a *ResponseError.
+func (utilsClient *BSSClient) do(ctx context.Context, method, requestURL string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new request: %w", err)
+	}
+	if utilsClient.token != "" {
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", utilsClient.token))
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
-	jsonBytes, err := json.Marshal(bssEntry)
+	resp, err := utilsClient.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal BSS entry: %w", err)
+		return nil, fmt.Errorf("failed to %s BSS boot parameters: %w", method, err)
 	}
+	defer resp.Body.Close()
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonBytes))
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to create new request: %w", err)
+		return nil, fmt.Errorf("failed to read BSS response: %w", err)
 	}
-	if utilsClient.token != "" {
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", utilsClient.token))
+
+	if resp.StatusCode < 200 || 299 < resp.StatusCode {
+		responseError := &ResponseError{
+			Method:     method,
+			URL:        requestURL,
+			StatusCode: resp.StatusCode,
+			Body:       string(bodyBytes),
+		}
+
+		var problem ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &problem); err == nil && (problem.Title != "" || problem.Detail != "") {
+			responseError.Problem = &problem
+		}
+
+		return nil, responseError
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := utilsClient.httpClient.Do(req)
+	return bodyBytes, nil
+}
+
+// UploadEntryToBSS - Uploads an entry to BSS.
+func (utilsClient *BSSClient) UploadEntryToBSS(ctx context.Context, bssEntry bssTypes.BootParams, method string) (string, error) {
+	url := fmt.Sprintf("%s/boot/v1/bootparameters", utilsClient.baseURL)
+
+	jsonBytes, err := json.Marshal(bssEntry)
 	if err != nil {
-		return "", fmt.Errorf("failed to %s BSS entry: %w", method, err)
+		return "", fmt.Errorf("failed to marshal BSS entry: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := ioutil.ReadAll(resp.Body)
-		return "", fmt.Errorf("failed to %s BSS entry: %s", method, string(bodyBytes))
+	if _, err := utilsClient.do(ctx, method, url, bytes.NewBuffer(jsonBytes)); err != nil {
+		return "", err
 	}
 
 	jsonPrettyBytes, _ := json.MarshalIndent(bssEntry, "", "  ")
@@ -90,40 +164,82 @@ func (utilsClient *BSSClient) UploadEntryToBSS(bssEntry bssTypes.BootParams, met
 	return string(jsonPrettyBytes), nil
 }
 
-// GetBSSBootparametersForXname - Gets the BSS boot parameters for a given xname.
-func (utilsClient *BSSClient) GetBSSBootparametersByName(name string) (*bssTypes.BootParams, error) {
-	url := fmt.Sprintf("%s/boot/v1/bootparameters?name=%s", utilsClient.baseURL, name)
+// PatchBSSBootparameters - Updates only the fields of an existing BSS entry that are set in the given entry. BSS
+// merges the given cloud-init data into the existing cloud-init data.
+func (utilsClient *BSSClient) PatchBSSBootparameters(ctx context.Context, bssEntry bssTypes.BootParams) error {
+	_, err := utilsClient.UploadEntryToBSS(ctx, bssEntry, http.MethodPatch)
+	return err
+}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// GetBSSBootparametersByName - Gets the BSS boot parameters for a given name, such as an xname or Global.
+func (utilsClient *BSSClient) GetBSSBootparametersByName(ctx context.Context, name string) (*bssTypes.BootParams, error) {
+	bootParams, err := utilsClient.GetBSSBootparametersByNames(ctx, []string{name})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create new request: %s", err)
-	}
-	if utilsClient.token != "" {
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", utilsClient.token))
+		return nil, err
 	}
 
-	resp, err := utilsClient.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get BSS entry: %s", err)
+	return bootParams[name], nil
+}
+
+// GetBSSBootparametersByNames - Gets the BSS boot parameters for the given names with a single request. The result is
+// keyed by name. If BSS does not have boot parameters for any of the names, then a *NotFoundError is returned.
+func (utilsClient *BSSClient) GetBSSBootparametersByNames(ctx context.Context, names []string) (map[string]*bssTypes.BootParams, error) {
+	result := map[string]*bssTypes.BootParams{}
+	if len(names) == 0 {
+		// Without any names BSS would give back every entry
+		return result, nil
 	}
 
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+	query := url.Values{}
+	for _, name := range names {
+		query.Add("name", name)
+	}
+	requestURL := fmt.Sprintf("%s/boot/v1/bootparameters?%s", utilsClient.baseURL, query.Encode())
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get BSS entry: %s", string(bodyBytes))
+	bodyBytes, err := utilsClient.do(ctx, http.MethodGet, requestURL, nil)
+	if responseError, ok := err.(*ResponseError); ok && responseError.StatusCode == http.StatusNotFound {
+		return nil, &NotFoundError{Names: names}
+	} else if err != nil {
+		return nil, err
 	}
 
 	// BSS gives back an array.
 	var bssEntries []bssTypes.BootParams
 	err = json.Unmarshal(bodyBytes, &bssEntries)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal BSS entries: %s", err)
+		return nil, fmt.Errorf("failed to unmarshal BSS entries: %w", err)
 	}
 
-	// We should only ever get one entry for a given name.
-	if len(bssEntries) != 1 {
-		return nil, fmt.Errorf("unexpected number of BSS entries: %+v", bssEntries)
+	// Match up each entry with the names it was requested by
+	requested := map[string]bool{}
+	for _, name := range names {
+		requested[name] = true
+	}
+
+	for i := range bssEntries {
+		for _, host := range bssEntries[i].Hosts {
+			if !requested[host] {
+				continue
+			}
+
+			// We should only ever get one entry for a given name.
+			if _, ok := result[host]; ok {
+				return nil, fmt.Errorf("unexpected number of BSS entries for (%s)", host)
+			}
+			result[host] = &bssEntries[i]
+		}
+	}
+
+	var missing []string
+	for name := range requested {
+		if _, ok := result[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return nil, &NotFoundError{Names: missing}
 	}
 
-	return &bssEntries[0], nil
+	return result, nil
 }
@@ -0,0 +1,161 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package bss
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Cray-HPE/hms-bss/pkg/bssTypes"
+	"github.com/stretchr/testify/suite"
+)
+
+type BSSClientTestSuite struct {
+	suite.Suite
+
+	server   *httptest.Server
+	client   *BSSClient
+	requests []*http.Request
+
+	entries []bssTypes.BootParams
+}
+
+func (suite *BSSClientTestSuite) SetupTest() {
+	suite.requests = nil
+	suite.entries = []bssTypes.BootParams{
+		{Hosts: []string{"Global"}, Params: "global"},
+		{Hosts: []string{"x3000c0s1b0n0"}, Params: "ncn-m001"},
+		{Hosts: []string{"x3000c0s2b0n0"}, Params: "ncn-m002"},
+	}
+
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.requests = append(suite.requests, r)
+
+		switch r.Method {
+		case http.MethodGet:
+			var found []bssTypes.BootParams
+			for _, name := range r.URL.Query()["name"] {
+				for _, entry := range suite.entries {
+					if entry.Hosts[0] == name {
+						found = append(found, entry)
+					}
+				}
+			}
+
+			if len(found) == 0 {
+				w.Header().Set("Content-Type", "application/problem+json")
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"type":"about:blank","title":"Not Found","detail":"cannot find host","status":404}`))
+				return
+			}
+
+			json.NewEncoder(w).Encode(found)
+		case http.MethodPatch:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"type":"about:blank","title":"Bad Request","detail":"method not supported","status":400}`))
+		}
+	}))
+
+	suite.client = NewBSSClient(suite.server.URL, suite.server.Client(), "token")
+}
+
+func (suite *BSSClientTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *BSSClientTestSuite) TestGetByNames() {
+	bootParams, err := suite.client.GetBSSBootparametersByNames(context.Background(), []string{"x3000c0s1b0n0", "x3000c0s2b0n0"})
+	suite.NoError(err)
+	suite.Len(bootParams, 2)
+	suite.Equal("ncn-m001", bootParams["x3000c0s1b0n0"].Params)
+	suite.Equal("ncn-m002", bootParams["x3000c0s2b0n0"].Params)
+
+	// Retrieved with a single request
+	suite.Len(suite.requests, 1)
+	suite.Equal([]string{"x3000c0s1b0n0", "x3000c0s2b0n0"}, suite.requests[0].URL.Query()["name"])
+	suite.Equal("Bearer token", suite.requests[0].Header.Get("Authorization"))
+}
+
+func (suite *BSSClientTestSuite) TestGetByName() {
+	bootParams, err := suite.client.GetBSSBootparametersByName(context.Background(), "Global")
+	suite.NoError(err)
+	suite.Equal("global", bootParams.Params)
+}
+
+func (suite *BSSClientTestSuite) TestGetByNames_SomeMissing() {
+	_, err := suite.client.GetBSSBootparametersByNames(context.Background(), []string{"x3000c0s1b0n0", "x3000c0s9b0n0"})
+
+	var notFoundError *NotFoundError
+	suite.True(errors.As(err, &notFoundError))
+	suite.Equal([]string{"x3000c0s9b0n0"}, notFoundError.Names)
+}
+
+func (suite *BSSClientTestSuite) TestGetByNames_NoneFound() {
+	_, err := suite.client.GetBSSBootparametersByNames(context.Background(), []string{"x3000c0s9b0n0"})
+	suite.EqualError(err, "BSS boot parameters not found for x3000c0s9b0n0")
+}
+
+func (suite *BSSClientTestSuite) TestGetByNames_Empty() {
+	bootParams, err := suite.client.GetBSSBootparametersByNames(context.Background(), nil)
+	suite.NoError(err)
+	suite.Empty(bootParams)
+	suite.Empty(suite.requests)
+}
+
+func (suite *BSSClientTestSuite) TestPatch() {
+	err := suite.client.PatchBSSBootparameters(context.Background(), bssTypes.BootParams{Hosts: []string{"x3000c0s1b0n0"}})
+	suite.NoError(err)
+	suite.Len(suite.requests, 1)
+	suite.Equal(http.MethodPatch, suite.requests[0].Method)
+	suite.Equal("application/json", suite.requests[0].Header.Get("Content-Type"))
+}
+
+func (suite *BSSClientTestSuite) TestProblemDetails() {
+	_, err := suite.client.UploadEntryToBSS(context.Background(), bssTypes.BootParams{}, http.MethodPost)
+
+	var responseError *ResponseError
+	suite.True(errors.As(err, &responseError))
+	suite.Equal(http.StatusBadRequest, responseError.StatusCode)
+	suite.Equal(&ProblemDetails{Type: "about:blank", Title: "Bad Request", Detail: "method not supported", Status: 400}, responseError.Problem)
+	suite.Contains(err.Error(), "failed with status 400: Bad Request: method not supported")
+}
+
+func (suite *BSSClientTestSuite) TestCanceledContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := suite.client.GetBSSBootparametersByName(ctx, "Global")
+	suite.True(errors.Is(err, context.Canceled))
+	suite.Empty(suite.requests)
+}
+
+func TestBSSClientTestSuite(t *testing.T) {
+	suite.Run(t, new(BSSClientTestSuite))
+}
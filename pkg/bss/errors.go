@@ -0,0 +1,75 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package bss
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNetworkNotFound     = errors.New("network not found")
+	ErrSubnetNotFound      = errors.New("subnet not found")
+	ErrReservationNotFound = errors.New("IP reservation not found")
+)
+
+// NetworkError is returned when the IPAM metadata, route files, or host records of the system cannot be built from a
+// SLS network. The xname of the management NCN and the subnet are set when known.
+type NetworkError struct {
+	Xname   string
+	Network string
+	Subnet  string
+	Err     error
+}
+
+func (e *NetworkError) Error() string {
+	location := fmt.Sprintf("network (%s)", e.Network)
+	if e.Subnet != "" {
+		location = fmt.Sprintf("subnet (%s) in network (%s)", e.Subnet, e.Network)
+	}
+
+	if e.Xname != "" {
+		return fmt.Sprintf("%s: %s: %v", e.Xname, location, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", location, e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// HostRecordsError is returned when some of the host records of the BSS Global boot parameters cannot be built, and
+// contains the error for each of them.
+type HostRecordsError struct {
+	Errors []error
+}
+
+func (e *HostRecordsError) Error() string {
+	var messages []string
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+
+	return fmt.Sprintf("unable to build host records: %s", strings.Join(messages, ", "))
+}
@@ -0,0 +1,127 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package bss
+
+import (
+	"net"
+	"testing"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+)
+
+type GlobalHostRecordsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *GlobalHostRecordsTestSuite) networks() sls_common.NetworkArray {
+	return sls_common.NetworkArray{
+		{
+			Name: "CAN",
+			ExtraPropertiesRaw: sls_common.NetworkExtraProperties{
+				CIDR: "10.102.4.0/24",
+			},
+		},
+		{
+			Name: "HMN",
+			ExtraPropertiesRaw: sls_common.NetworkExtraProperties{
+				CIDR: "10.254.0.0/17",
+				Subnets: []sls_common.IPV4Subnet{
+					{
+						Name: "network_hardware",
+						CIDR: "10.254.0.0/24",
+						IPReservations: []sls_common.IPReservation{
+							{Name: "sw-leaf-bmc-001", IPAddress: net.ParseIP("10.254.0.4")},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "NMN",
+			ExtraPropertiesRaw: sls_common.NetworkExtraProperties{
+				CIDR: "10.252.0.0/17",
+				Subnets: []sls_common.IPV4Subnet{
+					{
+						Name: "bootstrap_dhcp",
+						CIDR: "10.252.1.0/24",
+						IPReservations: []sls_common.IPReservation{
+							{Name: "kubeapi-vip", IPAddress: net.ParseIP("10.252.1.2")},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (suite *GlobalHostRecordsTestSuite) TestWellKnownAndPitHostRecords() {
+	wellKnownHostRecords := configs.WellKnownHostRecords{
+		{Network: "NMN", Subnet: "bootstrap_dhcp", Reservation: "kubeapi-vip", Aliases: []string{"kubeapi-vip", "kubeapi-vip.nmn"}},
+	}
+	pitHostRecord := HostRecord{IP: "10.252.1.4", Aliases: []string{"pit", "pit.nmn"}}
+
+	hostRecords, err := GetBSSGlobalHostRecords(nil, suite.networks(), wellKnownHostRecords, &pitHostRecord)
+	suite.NoError(err)
+	suite.Equal(HostRecords{
+		{IP: "10.252.1.2", Aliases: []string{"kubeapi-vip", "kubeapi-vip.nmn"}},
+		{IP: "10.252.1.4", Aliases: []string{"pit", "pit.nmn"}},
+		{IP: "10.254.0.4", Aliases: []string{"sw-leaf-bmc-001"}},
+	}, hostRecords)
+}
+
+func (suite *GlobalHostRecordsTestSuite) TestMissingHostRecords() {
+	_, err := GetBSSGlobalHostRecords(nil, suite.networks(), configs.DefaultWellKnownHostRecords, nil)
+	suite.EqualError(err, "unable to build host records: "+
+		"rgw-vip: subnet (bootstrap_dhcp) in network (NMN): IP reservation not found, "+
+		"istio-ingressgateway: network (NMNLB): network not found")
+
+	var hostRecordsErr *HostRecordsError
+	suite.Require().ErrorAs(err, &hostRecordsErr)
+	suite.Require().Len(hostRecordsErr.Errors, 2)
+	suite.ErrorIs(hostRecordsErr.Errors[0], ErrReservationNotFound)
+	suite.ErrorIs(hostRecordsErr.Errors[1], ErrNetworkNotFound)
+
+	var networkErr *NetworkError
+	suite.Require().ErrorAs(hostRecordsErr.Errors[0], &networkErr)
+	suite.Equal(NetworkError{Xname: "rgw-vip", Network: "NMN", Subnet: "bootstrap_dhcp", Err: ErrReservationNotFound}, *networkErr)
+}
+
+func (suite *GlobalHostRecordsTestSuite) TestFindHostRecord() {
+	hostRecords := HostRecords{
+		{IP: "10.252.1.2", Aliases: []string{"kubeapi-vip", "kubeapi-vip.nmn"}},
+		{IP: "10.252.1.4", Aliases: []string{"pit", "pit.nmn"}},
+	}
+
+	hostRecord, found := FindHostRecord(hostRecords, "pit")
+	suite.True(found)
+	suite.Equal("10.252.1.4", hostRecord.IP)
+
+	_, found = FindHostRecord(hostRecords, "rgw-vip")
+	suite.False(found)
+}
+
+func TestGlobalHostRecordsTestSuite(t *testing.T) {
+	suite.Run(t, new(GlobalHostRecordsTestSuite))
+}
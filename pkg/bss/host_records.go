@@ -0,0 +1,186 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package bss
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// key identifies a host record by its IP and aliases. The order of the aliases does not matter.
+func (r HostRecord) key() string {
+	aliases := append([]string{}, r.Aliases...)
+	sort.Strings(aliases)
+
+	return fmt.Sprintf("%s %s", r.IP, strings.Join(aliases, ","))
+}
+
+// sharesAlias determines if the two host records have any alias in common.
+func (r HostRecord) sharesAlias(other HostRecord) bool {
+	for _, alias := range r.Aliases {
+		for _, otherAlias := range other.Aliases {
+			if alias == otherAlias {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (r HostRecord) String() string {
+	return fmt.Sprintf("%s %s", r.IP, strings.Join(r.Aliases, " "))
+}
+
+// Text will format the host records like a hosts file with one host record per line, so changes to them can be shown
+// as a diff.
+func (hostRecords HostRecords) Text() string {
+	var sb strings.Builder
+	for _, hostRecord := range hostRecords {
+		sb.WriteString(hostRecord.String())
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// HostRecordChange is a host record that was updated while reconciling host records.
+type HostRecordChange struct {
+	Previous HostRecord
+	Current  HostRecord
+}
+
+// HostRecordsReport describes the changes made while reconciling host records.
+type HostRecordsReport struct {
+	Added   []HostRecord
+	Removed []HostRecord
+	Changed []HostRecordChange
+
+	// Number of existing host records that are not derived from SLS, and were kept as is
+	Unknown int
+}
+
+// HasChanges determines if any host records were added, removed, or changed.
+func (report HostRecordsReport) HasChanges() bool {
+	return len(report.Added) != 0 || len(report.Removed) != 0 || len(report.Changed) != 0
+}
+
+// Summary describes each change made while reconciling host records.
+func (report HostRecordsReport) Summary() []string {
+	var summary []string
+	for _, record := range report.Added {
+		summary = append(summary, fmt.Sprintf("+ %s", record))
+	}
+	for _, record := range report.Removed {
+		summary = append(summary, fmt.Sprintf("- %s", record))
+	}
+	for _, change := range report.Changed {
+		summary = append(summary, fmt.Sprintf("~ %s -> %s", change.Previous, change.Current))
+	}
+
+	return summary
+}
+
+// ReconcileHostRecords will reconcile the existing host records with the expected host records derived from SLS. Host
+// records are compared as a set keyed by their IP and aliases, so the order of records or aliases does not matter.
+//
+// An expected host record that does not exist is added, unless an existing host record shares one of its aliases. In
+// that case the existing host record is changed to match the expected host record. Any remaining existing host records
+// sharing an alias with an expected host record are stale and removed. Existing host records that do not share an
+// alias with an expected host record are not derived from SLS, such as site added host records, and are kept as is.
+func ReconcileHostRecords(existing, expected HostRecords) (HostRecords, HostRecordsReport) {
+	var report HostRecordsReport
+
+	expectedKeys := map[string]bool{}
+	for _, record := range expected {
+		expectedKeys[record.key()] = true
+	}
+	existingKeys := map[string]bool{}
+	for _, record := range existing {
+		existingKeys[record.key()] = true
+	}
+
+	// The replacement for each existing host record that is changed, by index
+	replacements := map[int]HostRecord{}
+	var added HostRecords
+	for _, expectedRecord := range expected {
+		if existingKeys[expectedRecord.key()] {
+			// Already exists
+			continue
+		}
+
+		changed := false
+		for i, existingRecord := range existing {
+			if _, replaced := replacements[i]; replaced || expectedKeys[existingRecord.key()] {
+				continue
+			}
+
+			if existingRecord.sharesAlias(expectedRecord) {
+				replacements[i] = expectedRecord
+				report.Changed = append(report.Changed, HostRecordChange{Previous: existingRecord, Current: expectedRecord})
+				changed = true
+				break
+			}
+		}
+
+		if !changed {
+			added = append(added, expectedRecord)
+			report.Added = append(report.Added, expectedRecord)
+		}
+	}
+
+	var result HostRecords
+	for i, record := range existing {
+		if replacement, replaced := replacements[i]; replaced {
+			result = append(result, replacement)
+			continue
+		}
+
+		if !expectedKeys[record.key()] {
+			if sharesAliasWithAny(record, expected) {
+				// Stale host record derived from SLS
+				report.Removed = append(report.Removed, record)
+				continue
+			}
+
+			report.Unknown++
+		}
+
+		result = append(result, record)
+	}
+	result = append(result, added...)
+
+	return result, report
+}
+
+// sharesAliasWithAny determines if the host record shares an alias with any of the given host records.
+func sharesAliasWithAny(record HostRecord, records HostRecords) bool {
+	for _, other := range records {
+		if record.sharesAlias(other) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package bss
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReconcileHostRecordsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ReconcileHostRecordsTestSuite) TestReordered() {
+	existing := HostRecords{
+		{IP: "10.252.1.11", Aliases: []string{"kubeapi-vip.nmn", "kubeapi-vip"}},
+		{IP: "10.252.1.4", Aliases: []string{"ncn-m001.nmn", "ncn-m001"}},
+	}
+	expected := HostRecords{
+		{IP: "10.252.1.4", Aliases: []string{"ncn-m001.nmn", "ncn-m001"}},
+		{IP: "10.252.1.11", Aliases: []string{"kubeapi-vip", "kubeapi-vip.nmn"}},
+	}
+
+	result, report := ReconcileHostRecords(existing, expected)
+	suite.False(report.HasChanges())
+	suite.Equal(existing, result)
+}
+
+func (suite *ReconcileHostRecordsTestSuite) TestKeepUnknown() {
+	existing := HostRecords{
+		{IP: "10.252.1.4", Aliases: []string{"ncn-m001.nmn", "ncn-m001"}},
+		{IP: "172.30.0.10", Aliases: []string{"site-ldap"}},
+	}
+	expected := HostRecords{
+		{IP: "10.252.1.4", Aliases: []string{"ncn-m001.nmn", "ncn-m001"}},
+		{IP: "10.254.1.20", Aliases: []string{"sw-leaf-bmc-002"}},
+	}
+
+	result, report := ReconcileHostRecords(existing, expected)
+	suite.Equal(HostRecords{
+		{IP: "10.252.1.4", Aliases: []string{"ncn-m001.nmn", "ncn-m001"}},
+		{IP: "172.30.0.10", Aliases: []string{"site-ldap"}},
+		{IP: "10.254.1.20", Aliases: []string{"sw-leaf-bmc-002"}},
+	}, result)
+	suite.Equal(HostRecordsReport{
+		Added:   []HostRecord{{IP: "10.254.1.20", Aliases: []string{"sw-leaf-bmc-002"}}},
+		Unknown: 1,
+	}, report)
+	suite.Equal([]string{"+ 10.254.1.20 sw-leaf-bmc-002"}, report.Summary())
+}
+
+func (suite *ReconcileHostRecordsTestSuite) TestChangedAndRemoved() {
+	existing := HostRecords{
+		{IP: "10.254.1.20", Aliases: []string{"sw-leaf-bmc-002"}},
+		{IP: "10.252.1.4", Aliases: []string{"ncn-m001.nmn"}},
+		{IP: "10.252.1.99", Aliases: []string{"ncn-m001.nmn", "ncn-m001"}},
+	}
+	expected := HostRecords{
+		{IP: "10.252.1.4", Aliases: []string{"ncn-m001.nmn", "ncn-m001"}},
+		{IP: "10.254.1.21", Aliases: []string{"sw-leaf-bmc-002"}},
+	}
+
+	result, report := ReconcileHostRecords(existing, expected)
+	suite.Equal(HostRecords{
+		{IP: "10.254.1.21", Aliases: []string{"sw-leaf-bmc-002"}},
+		{IP: "10.252.1.4", Aliases: []string{"ncn-m001.nmn", "ncn-m001"}},
+	}, result)
+	suite.True(report.HasChanges())
+	suite.Equal([]string{
+		"- 10.252.1.99 ncn-m001.nmn ncn-m001",
+		"~ 10.252.1.4 ncn-m001.nmn -> 10.252.1.4 ncn-m001.nmn ncn-m001",
+		"~ 10.254.1.20 sw-leaf-bmc-002 -> 10.254.1.21 sw-leaf-bmc-002",
+	}, report.Summary())
+}
+
+func (suite *ReconcileHostRecordsTestSuite) TestText() {
+	hostRecords := HostRecords{
+		{IP: "10.252.1.2", Aliases: []string{"kubeapi-vip", "kubeapi-vip.nmn"}},
+		{IP: "10.254.0.4", Aliases: []string{"sw-leaf-bmc-001"}},
+	}
+
+	suite.Equal("10.252.1.2 kubeapi-vip kubeapi-vip.nmn\n10.254.0.4 sw-leaf-bmc-001\n", hostRecords.Text())
+	suite.Empty(HostRecords{}.Text())
+}
+
+func TestReconcileHostRecordsTestSuite(t *testing.T) {
+	suite.Run(t, new(ReconcileHostRecordsTestSuite))
+}
@@ -0,0 +1,206 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package bss
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	"github.com/mitchellh/mapstructure"
+)
+
+// NCNInterfaces describes the network interfaces of a management NCN.
+type NCNInterfaces struct {
+	// Parent device of each network from the existing IPAM metadata of the NCN. The key is the lower case network name.
+	ParentDevices map[string]string
+
+	// Parent device of each network from the existing IPAM metadata of the NCN, also when the parent device is
+	// overridden. The key is the lower case network name.
+	ExistingParentDevices map[string]string
+
+	// Parent device used for networks without an existing parent device
+	DefaultParentDevice string
+
+	// Format of the VLAN interface name of a network, formatted with the parent device and the lower case network name
+	VLANInterfaceFormat string
+}
+
+// DefaultNCNInterfaces uses the default interface naming for all networks.
+var DefaultNCNInterfaces = NCNInterfaces{
+	DefaultParentDevice: configs.DefaultNCNInterfaceNaming.ParentDevice,
+	VLANInterfaceFormat: configs.DefaultNCNInterfaceNaming.VLANInterfaceFormat,
+}
+
+// ParentDevice will retrieve the parent device of the given lower case network name. Unless the existing IPAM metadata
+// says otherwise, the CHN uses the high speed network device.
+func (i NCNInterfaces) ParentDevice(network string) string {
+	if parentDevice, ok := i.ParentDevices[network]; ok && parentDevice != "" {
+		return parentDevice
+	}
+	if network == "chn" {
+		return CHNParentDevice
+	}
+
+	return i.DefaultParentDevice
+}
+
+// VLANInterface will build the name of the VLAN interface of the given lower case network name, such as bond0.nmn0.
+func (i NCNInterfaces) VLANInterface(network string) string {
+	return fmt.Sprintf(i.VLANInterfaceFormat, i.ParentDevice(network), network)
+}
+
+// RouteFilePaths will build the paths of the route files of the given lower case network name that are built by this
+// tool, using both the current and existing parent device of the network.
+func (i NCNInterfaces) RouteFilePaths(network string) []string {
+	paths := []string{RouteFilePathPrefix + i.VLANInterface(network)}
+	if existingParentDevice := i.ExistingParentDevices[network]; existingParentDevice != "" && existingParentDevice != i.ParentDevice(network) {
+		paths = append(paths, RouteFilePathPrefix+fmt.Sprintf(i.VLANInterfaceFormat, existingParentDevice, network))
+	}
+
+	return paths
+}
+
+// DetermineNCNInterfaces will determine the network interfaces of a management NCN. The parent device of each network
+// is taken from the existing IPAM metadata of the NCN in BSS, unless the override provides a parent device for all
+// networks. Networks without an existing parent device use the parent device shared by all existing networks, or the
+// default parent device. The VLAN interface format is taken from the override, or is inferred from the paths of the
+// existing NMN and HMN route files in the write_files of the NCN. An error is returned when the existing route files
+// do not follow a format that can be inferred, so the route files are not silently renamed.
+func DetermineNCNInterfaces(existingIPAMRaw, existingWriteFilesRaw interface{}, override configs.NCNInterfaceNaming) (NCNInterfaces, error) {
+	if err := override.Validate(); err != nil {
+		return NCNInterfaces{}, err
+	}
+
+	interfaces := DefaultNCNInterfaces
+	interfaces.ParentDevices = map[string]string{}
+	interfaces.ExistingParentDevices = map[string]string{}
+
+	var existingIPAM CloudInitIPAM
+	if err := mapstructure.WeakDecode(existingIPAMRaw, &existingIPAM); err != nil {
+		return NCNInterfaces{}, fmt.Errorf("failed to decode existing IPAM metadata: %w", err)
+	}
+
+	for network, ipamNetwork := range existingIPAM {
+		if ipamNetwork.ParentDevice != "" {
+			interfaces.ExistingParentDevices[network] = ipamNetwork.ParentDevice
+		}
+	}
+
+	if override.ParentDevice != "" {
+		interfaces.DefaultParentDevice = override.ParentDevice
+	} else {
+		// If every existing management network shares the same parent device, then networks without one use it too
+		existingParentDevices := map[string]bool{}
+		for network, ipamNetwork := range existingIPAM {
+			if ipamNetwork.ParentDevice == "" {
+				continue
+			}
+
+			interfaces.ParentDevices[network] = ipamNetwork.ParentDevice
+			if network != "chn" {
+				existingParentDevices[ipamNetwork.ParentDevice] = true
+			}
+		}
+		if len(existingParentDevices) == 1 {
+			for parentDevice := range existingParentDevices {
+				interfaces.DefaultParentDevice = parentDevice
+			}
+		}
+	}
+
+	if override.VLANInterfaceFormat != "" {
+		interfaces.VLANInterfaceFormat = override.VLANInterfaceFormat
+		return interfaces, nil
+	}
+
+	vlanInterfaceFormat, err := inferVLANInterfaceFormat(existingWriteFilesRaw, existingIPAM, interfaces)
+	if err != nil {
+		return NCNInterfaces{}, err
+	}
+	if vlanInterfaceFormat != "" {
+		interfaces.VLANInterfaceFormat = vlanInterfaceFormat
+	}
+
+	return interfaces, nil
+}
+
+// inferVLANInterfaceFormat will infer the VLAN interface format from the paths of the existing NMN and HMN route files.
+// The VLAN interface of a route file needs to start with a known parent device of its network, and contain the network
+// name once. If there are no existing route files, then an empty format is returned.
+func inferVLANInterfaceFormat(existingWriteFilesRaw interface{}, existingIPAM CloudInitIPAM, interfaces NCNInterfaces) (string, error) {
+	var existingWriteFiles []WriteFile
+	if err := mapstructure.WeakDecode(existingWriteFilesRaw, &existingWriteFiles); err != nil {
+		return "", fmt.Errorf("failed to decode existing write_files: %w", err)
+	}
+
+	var inferredFormat, inferredFrom string
+	for _, writeFile := range existingWriteFiles {
+		network, ok := routeFileNetwork(writeFile.Path)
+		if !ok {
+			continue
+		}
+
+		vlanInterface := strings.TrimPrefix(writeFile.Path, RouteFilePathPrefix)
+		parentDevices := []string{
+			existingIPAM[network].ParentDevice,
+			interfaces.ParentDevice(network),
+			configs.DefaultNCNInterfaceNaming.ParentDevice,
+		}
+
+		format, ok := vlanInterfaceFormat(vlanInterface, network, parentDevices)
+		if !ok {
+			return "", fmt.Errorf("unable to determine the VLAN interface format from the existing route file (%s) as it does not match the default format (%s), provide a vlan_interface_format with an NCN interface override", writeFile.Path, configs.DefaultNCNInterfaceNaming.VLANInterfaceFormat)
+		}
+
+		if inferredFormat != "" && inferredFormat != format {
+			return "", fmt.Errorf("existing route files (%s) and (%s) use different VLAN interface formats (%s) and (%s), provide a vlan_interface_format with an NCN interface override", inferredFrom, writeFile.Path, inferredFormat, format)
+		}
+		inferredFormat, inferredFrom = format, writeFile.Path
+	}
+
+	return inferredFormat, nil
+}
+
+// vlanInterfaceFormat will build the VLAN interface format that produces the VLAN interface from one of the parent
+// devices and the network name.
+func vlanInterfaceFormat(vlanInterface, network string, parentDevices []string) (string, bool) {
+	if strings.Contains(vlanInterface, "%") {
+		return "", false
+	}
+
+	for _, parentDevice := range parentDevices {
+		if parentDevice == "" || !strings.HasPrefix(vlanInterface, parentDevice) {
+			continue
+		}
+
+		remainder := strings.TrimPrefix(vlanInterface, parentDevice)
+		if strings.Count(remainder, network) != 1 {
+			continue
+		}
+
+		return "%s" + strings.Replace(remainder, network, "%s", 1), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,172 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package bss
+
+import (
+	"net"
+	"testing"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+)
+
+type NCNInterfacesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *NCNInterfacesTestSuite) existingIPAM(parentDevice string) map[string]interface{} {
+	return map[string]interface{}{
+		"hmn": map[string]interface{}{"gateway": "10.254.0.1", "ip": "10.254.1.10/17", "parent_device": parentDevice, "vlanid": float64(4)},
+		"nmn": map[string]interface{}{"gateway": "10.252.0.1", "ip": "10.252.1.10/17", "parent_device": parentDevice, "vlanid": float64(2)},
+	}
+}
+
+func (suite *NCNInterfacesTestSuite) TestDefault() {
+	interfaces, err := DetermineNCNInterfaces(nil, nil, configs.NCNInterfaceNaming{})
+	suite.NoError(err)
+	suite.Equal("bond0", interfaces.ParentDevice("nmn"))
+	suite.Equal("bond0.nmn0", interfaces.VLANInterface("nmn"))
+}
+
+func (suite *NCNInterfacesTestSuite) TestFromExistingIPAM() {
+	existingIPAM := suite.existingIPAM("bond1")
+	existingIPAM["mtl"] = map[string]interface{}{"gateway": "10.1.0.1", "ip": "10.1.1.10/16", "parent_device": "bond0", "vlanid": float64(0)}
+
+	interfaces, err := DetermineNCNInterfaces(existingIPAM, nil, configs.NCNInterfaceNaming{})
+	suite.NoError(err)
+	suite.Equal("bond1.nmn0", interfaces.VLANInterface("nmn"))
+	suite.Equal("bond0", interfaces.ParentDevice("mtl"))
+
+	// The existing networks do not share a parent device, so the default is used for networks without one
+	suite.Equal("bond0", interfaces.ParentDevice("cmn"))
+}
+
+func (suite *NCNInterfacesTestSuite) TestSharedParentDevice() {
+	interfaces, err := DetermineNCNInterfaces(suite.existingIPAM("bond1"), nil, configs.NCNInterfaceNaming{})
+	suite.NoError(err)
+	suite.Equal("bond1", interfaces.ParentDevice("cmn"))
+}
+
+func (suite *NCNInterfacesTestSuite) TestOverride() {
+	interfaces, err := DetermineNCNInterfaces(suite.existingIPAM("bond1"), nil, configs.NCNInterfaceNaming{
+		ParentDevice:        "bond2",
+		VLANInterfaceFormat: "%s.%s",
+	})
+	suite.NoError(err)
+	suite.Equal("bond2", interfaces.ParentDevice("nmn"))
+	suite.Equal("bond2.hmn", interfaces.VLANInterface("hmn"))
+}
+
+func (suite *NCNInterfacesTestSuite) existingWriteFiles(vlanInterfaces ...string) []interface{} {
+	writeFiles := []interface{}{
+		map[string]interface{}{"content": "c2l0ZQ==", "encoding": "b64", "path": "/etc/site.conf"},
+		map[string]interface{}{"content": "0.0.0.0/0 10.102.4.1 - bond0.can0", "path": "/etc/sysconfig/network/ifroute-bond0.can0"},
+	}
+	for _, vlanInterface := range vlanInterfaces {
+		writeFiles = append(writeFiles, map[string]interface{}{
+			"content": "10.107.0.0/22 10.254.0.1 - " + vlanInterface,
+			"path":    "/etc/sysconfig/network/ifroute-" + vlanInterface,
+		})
+	}
+
+	return writeFiles
+}
+
+func (suite *NCNInterfacesTestSuite) TestFormatFromExistingRouteFiles() {
+	interfaces, err := DetermineNCNInterfaces(suite.existingIPAM("bond1"), suite.existingWriteFiles("bond1.nmn", "bond1.hmn"), configs.NCNInterfaceNaming{})
+	suite.NoError(err)
+	suite.Equal("%s.%s", interfaces.VLANInterfaceFormat)
+	suite.Equal("bond1.hmn", interfaces.VLANInterface("hmn"))
+}
+
+func (suite *NCNInterfacesTestSuite) TestFormatFromDefaultRouteFiles() {
+	interfaces, err := DetermineNCNInterfaces(suite.existingIPAM("bond0"), suite.existingWriteFiles("bond0.nmn0", "bond0.hmn0"), configs.NCNInterfaceNaming{})
+	suite.NoError(err)
+	suite.Equal("%s.%s0", interfaces.VLANInterfaceFormat)
+}
+
+func (suite *NCNInterfacesTestSuite) TestFormatFromRouteFilesWithNewParentDevice() {
+	// The route files were written for bond0, and are rewritten for the parent device given by the override
+	interfaces, err := DetermineNCNInterfaces(suite.existingIPAM("bond0"), suite.existingWriteFiles("bond0.nmn"), configs.NCNInterfaceNaming{
+		ParentDevice: "bond1",
+	})
+	suite.NoError(err)
+	suite.Equal("bond1.nmn", interfaces.VLANInterface("nmn"))
+
+	// The route file of the existing parent device was also built by this tool
+	suite.Equal([]string{
+		"/etc/sysconfig/network/ifroute-bond1.nmn",
+		"/etc/sysconfig/network/ifroute-bond0.nmn",
+	}, interfaces.RouteFilePaths("nmn"))
+}
+
+func (suite *NCNInterfacesTestSuite) TestUnknownRouteFileFormat() {
+	_, err := DetermineNCNInterfaces(suite.existingIPAM("bond0"), suite.existingWriteFiles("nmn0"), configs.NCNInterfaceNaming{})
+	suite.EqualError(err, "unable to determine the VLAN interface format from the existing route file (/etc/sysconfig/network/ifroute-nmn0) as it does not match the default format (%s.%s0), provide a vlan_interface_format with an NCN interface override")
+}
+
+func (suite *NCNInterfacesTestSuite) TestInconsistentRouteFileFormats() {
+	_, err := DetermineNCNInterfaces(suite.existingIPAM("bond0"), suite.existingWriteFiles("bond0.nmn0", "bond0.hmn"), configs.NCNInterfaceNaming{})
+	suite.EqualError(err, "existing route files (/etc/sysconfig/network/ifroute-bond0.nmn0) and (/etc/sysconfig/network/ifroute-bond0.hmn) use different VLAN interface formats (%s.%s0) and (%s.%s), provide a vlan_interface_format with an NCN interface override")
+}
+
+func (suite *NCNInterfacesTestSuite) TestFormatOverrideIgnoresRouteFiles() {
+	interfaces, err := DetermineNCNInterfaces(suite.existingIPAM("bond0"), suite.existingWriteFiles("nmn0"), configs.NCNInterfaceNaming{
+		VLANInterfaceFormat: "%s.%s",
+	})
+	suite.NoError(err)
+	suite.Equal("bond0.nmn", interfaces.VLANInterface("nmn"))
+}
+
+func (suite *NCNInterfacesTestSuite) TestWriteFiles() {
+	interfaces, err := DetermineNCNInterfaces(suite.existingIPAM("bond1"), nil, configs.NCNInterfaceNaming{})
+	suite.NoError(err)
+
+	networks := sls_common.NetworkArray{
+		{
+			Name: "HMN_RVR",
+			ExtraPropertiesRaw: sls_common.NetworkExtraProperties{
+				CIDR: "10.107.0.0/17",
+				Subnets: []sls_common.IPV4Subnet{
+					{Name: "cabinet_3000", CIDR: "10.107.0.0/22", Gateway: net.ParseIP("10.107.0.1")},
+				},
+			},
+		},
+	}
+
+	writeFiles, err := GetWriteFiles(networks, CloudInitIPAM{
+		"hmn": {Gateway: "10.254.0.1", CIDR: "10.254.1.10/17", ParentDevice: "bond1"},
+	}, interfaces)
+	suite.NoError(err)
+	suite.Equal([]WriteFile{{
+		Content:     "10.107.0.0/22 10.254.0.1 - bond1.hmn0",
+		Owner:       "root:root",
+		Path:        "/etc/sysconfig/network/ifroute-bond1.hmn0",
+		Permissions: "0644",
+	}}, writeFiles)
+}
+
+func TestNCNInterfacesTestSuite(t *testing.T) {
+	suite.Run(t, new(NCNInterfacesTestSuite))
+}
@@ -0,0 +1,209 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package bss
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// WriteFileChangeAction describes how a write_files entry was changed.
+type WriteFileChangeAction string
+
+const (
+	WriteFileAdded    WriteFileChangeAction = "added"
+	WriteFileModified WriteFileChangeAction = "modified"
+	WriteFileRemoved  WriteFileChangeAction = "removed"
+)
+
+// WriteFileChange describes a change made to a single write_files entry while merging.
+type WriteFileChange struct {
+	Path   string
+	Action WriteFileChangeAction
+
+	// The previous value of the entry. Empty when the entry was added.
+	Previous WriteFile
+
+	// The current value of the entry. Empty when the entry was removed.
+	Current WriteFile
+}
+
+// Text will format the write_files entry for a diff, with the owner and permissions as header lines followed by the
+// content as is. An empty entry, such as the previous value of an added entry, has no text.
+func (f WriteFile) Text() string {
+	if f == (WriteFile{}) {
+		return ""
+	}
+
+	return fmt.Sprintf("owner: %s\npermissions: %s\ncontent:\n%s", f.Owner, f.Permissions, f.Content)
+}
+
+// routeFileNetwork determines the network of the route file at the path, if it looks like a route file of one of the
+// networks route files are built for, such as /etc/sysconfig/network/ifroute-bond0.nmn0. The VLAN interface name of the
+// route file needs to contain the network name to be recognized. This does not mean the route file was built by this
+// tool, as a site may add its own route files for these networks.
+func routeFileNetwork(path string) (string, bool) {
+	if !strings.HasPrefix(path, RouteFilePathPrefix) {
+		return "", false
+	}
+
+	vlanInterface := strings.ToLower(strings.TrimPrefix(path, RouteFilePathPrefix))
+	for _, network := range RouteFileNetworks {
+		if strings.Contains(vlanInterface, network) {
+			return network, true
+		}
+	}
+
+	return "", false
+}
+
+// MergeWriteFiles will merge the expected route files into the existing write_files of the cloud-init user data of a
+// NCN. The existing entries with the same path as an expected route file are replaced, and the other route files this
+// tool builds for the NCN interfaces are removed, such as the route file left behind by a renamed parent device. All
+// other entries are kept as is and in the same order, including route files of the same networks that were not built
+// by this tool. Expected route files without an existing entry are appended. The merged write_files are returned along
+// with the changes that were made. If no changes were made, then the existing write_files are returned unchanged.
+func MergeWriteFiles(existingWriteFilesRaw interface{}, expectedRouteFiles []WriteFile, interfaces NCNInterfaces) ([]interface{}, []WriteFileChange, error) {
+	existingWriteFiles, err := writeFilesList(existingWriteFilesRaw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	managedPaths := managedRouteFilePaths(interfaces)
+
+	expectedByPath := map[string]WriteFile{}
+	for _, routeFile := range expectedRouteFiles {
+		expectedByPath[routeFile.Path] = routeFile
+	}
+
+	var merged []interface{}
+	var changes []WriteFileChange
+	found := map[string]bool{}
+	for i, entryRaw := range existingWriteFiles {
+		var entry WriteFile
+		if err := mapstructure.WeakDecode(entryRaw, &entry); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode write_files entry %d: %w", i, err)
+		}
+
+		expected, isExpected := expectedByPath[entry.Path]
+		if !isExpected || found[entry.Path] {
+			if !managedPaths[entry.Path] && !isExpected {
+				// Not a route file built by this tool, keep it as is
+				merged = append(merged, entryRaw)
+				continue
+			}
+
+			// A stale or duplicate route file built by this tool
+			changes = append(changes, WriteFileChange{
+				Path:     entry.Path,
+				Action:   WriteFileRemoved,
+				Previous: entry,
+			})
+			continue
+		}
+		found[entry.Path] = true
+
+		if entry == expected {
+			merged = append(merged, entryRaw)
+			continue
+		}
+
+		merged = append(merged, expected)
+		changes = append(changes, WriteFileChange{
+			Path:     entry.Path,
+			Action:   WriteFileModified,
+			Previous: entry,
+			Current:  expected,
+		})
+	}
+
+	for _, routeFile := range expectedRouteFiles {
+		if found[routeFile.Path] {
+			continue
+		}
+
+		merged = append(merged, routeFile)
+		changes = append(changes, WriteFileChange{
+			Path:    routeFile.Path,
+			Action:  WriteFileAdded,
+			Current: routeFile,
+		})
+	}
+
+	if len(changes) == 0 {
+		return existingWriteFiles, nil, nil
+	}
+
+	return merged, changes, nil
+}
+
+// UnmanagedRouteFiles will find the paths of the existing route files of the networks route files are built for, that
+// were not built by this tool for the NCN interfaces. These route files are kept as is when merging, but may conflict
+// with the route files built by this tool.
+func UnmanagedRouteFiles(existingWriteFilesRaw interface{}, interfaces NCNInterfaces) ([]string, error) {
+	existingWriteFiles, err := writeFilesList(existingWriteFilesRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	managedPaths := managedRouteFilePaths(interfaces)
+
+	var unmanaged []string
+	for i, entryRaw := range existingWriteFiles {
+		var entry WriteFile
+		if err := mapstructure.WeakDecode(entryRaw, &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode write_files entry %d: %w", i, err)
+		}
+
+		if _, ok := routeFileNetwork(entry.Path); ok && !managedPaths[entry.Path] {
+			unmanaged = append(unmanaged, entry.Path)
+		}
+	}
+
+	return unmanaged, nil
+}
+
+func writeFilesList(writeFilesRaw interface{}) ([]interface{}, error) {
+	switch writeFiles := writeFilesRaw.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		return writeFiles, nil
+	default:
+		return nil, fmt.Errorf("unexpected write_files type (%T)", writeFilesRaw)
+	}
+}
+
+// managedRouteFilePaths builds the set of route file paths this tool builds for the NCN interfaces.
+func managedRouteFilePaths(interfaces NCNInterfaces) map[string]bool {
+	managedPaths := map[string]bool{}
+	for _, network := range RouteFileNetworks {
+		for _, path := range interfaces.RouteFilePaths(network) {
+			managedPaths[path] = true
+		}
+	}
+
+	return managedPaths
+}
@@ -0,0 +1,234 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package bss
+
+import (
+	"testing"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/diff"
+	"github.com/stretchr/testify/suite"
+)
+
+type MergeWriteFilesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MergeWriteFilesTestSuite) routeFile(network, content string) WriteFile {
+	return WriteFile{
+		Content:     content,
+		Owner:       "root:root",
+		Path:        "/etc/sysconfig/network/ifroute-bond0." + network + "0",
+		Permissions: "0644",
+	}
+}
+
+func (suite *MergeWriteFilesTestSuite) siteEntry() map[string]interface{} {
+	return map[string]interface{}{
+		"content":     "c2l0ZQ==",
+		"encoding":    "b64",
+		"owner":       "root:root",
+		"path":        "/etc/site.conf",
+		"permissions": "0600",
+	}
+}
+
+func (suite *MergeWriteFilesTestSuite) routeFileEntry(network, content string) map[string]interface{} {
+	return map[string]interface{}{
+		"content":     content,
+		"owner":       "root:root",
+		"path":        "/etc/sysconfig/network/ifroute-bond0." + network + "0",
+		"permissions": "0644",
+	}
+}
+
+func (suite *MergeWriteFilesTestSuite) TestNoChanges() {
+	existing := []interface{}{
+		suite.routeFileEntry("nmn", "10.106.0.0/22 10.252.0.1 - bond0.nmn0"),
+		suite.siteEntry(),
+	}
+
+	merged, changes, err := MergeWriteFiles(existing, []WriteFile{
+		suite.routeFile("nmn", "10.106.0.0/22 10.252.0.1 - bond0.nmn0"),
+	}, DefaultNCNInterfaces)
+	suite.NoError(err)
+	suite.Empty(changes)
+	suite.Equal(existing, merged)
+}
+
+func (suite *MergeWriteFilesTestSuite) TestPreserveUnrelatedEntries() {
+	existing := []interface{}{
+		suite.siteEntry(),
+		suite.routeFileEntry("nmn", "10.106.0.0/22 10.252.0.1 - bond0.nmn0"),
+		suite.routeFileEntry("can", "0.0.0.0/0 10.102.4.1 - bond0.can0"),
+	}
+
+	expectedNMN := suite.routeFile("nmn", "10.106.0.0/22 10.252.0.1 - bond0.nmn0\n10.107.0.0/22 10.252.0.1 - bond0.nmn0")
+	expectedHMN := suite.routeFile("hmn", "10.107.0.0/22 10.254.0.1 - bond0.hmn0")
+
+	merged, changes, err := MergeWriteFiles(existing, []WriteFile{expectedNMN, expectedHMN}, DefaultNCNInterfaces)
+	suite.NoError(err)
+
+	// Unrelated entries are kept as is, and new route files are appended
+	suite.Equal([]interface{}{
+		suite.siteEntry(),
+		expectedNMN,
+		suite.routeFileEntry("can", "0.0.0.0/0 10.102.4.1 - bond0.can0"),
+		expectedHMN,
+	}, merged)
+
+	suite.Len(changes, 2)
+	suite.Equal(WriteFileModified, changes[0].Action)
+	suite.Equal(expectedNMN.Path, changes[0].Path)
+	suite.Equal(expectedNMN, changes[0].Current)
+
+	suite.Equal(WriteFileAdded, changes[1].Action)
+	suite.Equal(expectedHMN.Path, changes[1].Path)
+	suite.Equal(WriteFile{}, changes[1].Previous)
+	suite.Equal(expectedHMN, changes[1].Current)
+}
+
+func (suite *MergeWriteFilesTestSuite) TestModifiedPermissions() {
+	existingEntry := suite.routeFileEntry("hmn", "10.107.0.0/22 10.254.0.1 - bond0.hmn0\n10.108.0.0/22 10.254.0.1 - bond0.hmn0")
+	existingEntry["permissions"] = "0600"
+
+	_, changes, err := MergeWriteFiles([]interface{}{existingEntry}, []WriteFile{
+		suite.routeFile("hmn", "10.107.0.0/22 10.254.0.1 - bond0.hmn0"),
+	}, DefaultNCNInterfaces)
+	suite.NoError(err)
+	suite.Len(changes, 1)
+	suite.Equal(WriteFileModified, changes[0].Action)
+	suite.Equal("0600", changes[0].Previous.Permissions)
+	suite.Equal("10.107.0.0/22 10.254.0.1 - bond0.hmn0\n10.108.0.0/22 10.254.0.1 - bond0.hmn0", changes[0].Previous.Content)
+	suite.Equal(suite.routeFile("hmn", "10.107.0.0/22 10.254.0.1 - bond0.hmn0"), changes[0].Current)
+
+	// The permissions are part of the diff of the change
+	unifiedDiff, err := diff.Unified(changes[0].Path, changes[0].Previous.Text(), changes[0].Current.Text())
+	suite.NoError(err)
+	suite.Contains(unifiedDiff, "-permissions: 0600\n+permissions: 0644\n")
+}
+
+func (suite *MergeWriteFilesTestSuite) TestText() {
+	suite.Empty(WriteFile{}.Text())
+	suite.Equal("owner: root:root\npermissions: 0644\ncontent:\n10.107.0.0/22 10.254.0.1 - bond0.hmn0",
+		suite.routeFile("hmn", "10.107.0.0/22 10.254.0.1 - bond0.hmn0").Text())
+}
+
+func (suite *MergeWriteFilesTestSuite) TestRenamedParentDevice() {
+	// The NMN VLAN interface moved from bond0 to bond1, so the route file of bond0 is stale
+	existing := []interface{}{
+		suite.routeFileEntry("nmn", "10.106.0.0/22 10.252.0.1 - bond0.nmn0"),
+		suite.siteEntry(),
+		suite.routeFileEntry("can", "0.0.0.0/0 10.102.4.1 - bond0.can0"),
+	}
+
+	interfaces := DefaultNCNInterfaces
+	interfaces.ParentDevices = map[string]string{"nmn": "bond1"}
+	interfaces.ExistingParentDevices = map[string]string{"nmn": "bond0"}
+
+	expectedNMN := suite.routeFile("nmn", "10.106.0.0/22 10.252.0.1 - bond1.nmn0")
+	expectedNMN.Path = "/etc/sysconfig/network/ifroute-bond1.nmn0"
+
+	merged, changes, err := MergeWriteFiles(existing, []WriteFile{expectedNMN}, interfaces)
+	suite.NoError(err)
+
+	suite.Equal([]interface{}{
+		suite.siteEntry(),
+		suite.routeFileEntry("can", "0.0.0.0/0 10.102.4.1 - bond0.can0"),
+		expectedNMN,
+	}, merged)
+
+	suite.Equal([]WriteFileChange{
+		{
+			Path:     "/etc/sysconfig/network/ifroute-bond0.nmn0",
+			Action:   WriteFileRemoved,
+			Previous: suite.routeFile("nmn", "10.106.0.0/22 10.252.0.1 - bond0.nmn0"),
+		},
+		{
+			Path:    "/etc/sysconfig/network/ifroute-bond1.nmn0",
+			Action:  WriteFileAdded,
+			Current: expectedNMN,
+		},
+	}, changes)
+}
+
+func (suite *MergeWriteFilesTestSuite) TestKeepUnmanagedRouteFiles() {
+	// A second NMN VLAN interface and a route file on another parent device were not built by this tool
+	secondNMN := suite.routeFileEntry("nmn", "10.106.0.0/22 10.252.0.1 - bond0.nmn1")
+	secondNMN["path"] = "/etc/sysconfig/network/ifroute-bond0.nmn1"
+	otherParentDevice := suite.routeFileEntry("hmn", "10.107.0.0/22 10.254.0.1 - bond1.hmn0")
+	otherParentDevice["path"] = "/etc/sysconfig/network/ifroute-bond1.hmn0"
+
+	existing := []interface{}{
+		suite.routeFileEntry("nmn", "10.106.0.0/22 10.252.0.1 - bond0.nmn0"),
+		secondNMN,
+		otherParentDevice,
+	}
+
+	expectedNMN := suite.routeFile("nmn", "10.106.0.0/22 10.252.0.1 - bond0.nmn0")
+
+	merged, changes, err := MergeWriteFiles(existing, []WriteFile{expectedNMN}, DefaultNCNInterfaces)
+	suite.NoError(err)
+	suite.Empty(changes)
+	suite.Equal(existing, merged)
+
+	unmanaged, err := UnmanagedRouteFiles(existing, DefaultNCNInterfaces)
+	suite.NoError(err)
+	suite.Equal([]string{
+		"/etc/sysconfig/network/ifroute-bond0.nmn1",
+		"/etc/sysconfig/network/ifroute-bond1.hmn0",
+	}, unmanaged)
+}
+
+func (suite *MergeWriteFilesTestSuite) TestDuplicateRouteFile() {
+	existing := []interface{}{
+		suite.routeFileEntry("hmn", "10.107.0.0/22 10.254.0.1 - bond0.hmn0"),
+		suite.routeFileEntry("hmn", "10.108.0.0/22 10.254.0.1 - bond0.hmn0"),
+	}
+
+	expectedHMN := suite.routeFile("hmn", "10.107.0.0/22 10.254.0.1 - bond0.hmn0")
+
+	merged, changes, err := MergeWriteFiles(existing, []WriteFile{expectedHMN}, DefaultNCNInterfaces)
+	suite.NoError(err)
+	suite.Equal([]interface{}{existing[0]}, merged)
+	suite.Len(changes, 1)
+	suite.Equal(WriteFileRemoved, changes[0].Action)
+}
+
+func (suite *MergeWriteFilesTestSuite) TestNoExistingWriteFiles() {
+	expected := []WriteFile{suite.routeFile("nmn", "10.106.0.0/22 10.252.0.1 - bond0.nmn0")}
+
+	merged, changes, err := MergeWriteFiles(nil, expected, DefaultNCNInterfaces)
+	suite.NoError(err)
+	suite.Equal([]interface{}{expected[0]}, merged)
+	suite.Len(changes, 1)
+	suite.Equal(WriteFileAdded, changes[0].Action)
+}
+
+func (suite *MergeWriteFilesTestSuite) TestUnexpectedType() {
+	_, _, err := MergeWriteFiles("foo", nil, DefaultNCNInterfaces)
+	suite.EqualError(err, "unexpected write_files type (string)")
+}
+
+func TestMergeWriteFilesTestSuite(t *testing.T) {
+	suite.Run(t, new(MergeWriteFilesTestSuite))
+}
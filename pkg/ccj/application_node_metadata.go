@@ -24,11 +24,12 @@ package ccj
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
 )
 
-func BuildApplicationNodeMetadata(paddle Paddle, existingMetadata configs.ApplicationNodeMetadataMap) (configs.ApplicationNodeMetadataMap, error) {
+func BuildApplicationNodeMetadata(paddle Paddle, existingMetadata configs.ApplicationNodeMetadataMap, nidAssignment configs.NIDAssignment) (configs.ApplicationNodeMetadataMap, error) {
 	metadata := configs.ApplicationNodeMetadataMap{}
 
 	for _, topologyNode := range paddle.Topology {
@@ -36,7 +37,7 @@ func BuildApplicationNodeMetadata(paddle Paddle, existingMetadata configs.Applic
 			continue
 		}
 
-		extraProperties, err := BuildNodeExtraProperties(topologyNode)
+		extraProperties, err := BuildNodeExtraProperties(topologyNode, nidAssignment)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build node extra properties: %w", err)
 		}
@@ -45,7 +46,7 @@ func BuildApplicationNodeMetadata(paddle Paddle, existingMetadata configs.Applic
 			continue
 		}
 
-		xname, err := BuildNodeXname(topologyNode, paddle, extraProperties)
+		xname, err := BuildNodeXname(topologyNode, paddle, extraProperties, existingMetadata)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build node xname: %w", err)
 		}
@@ -66,3 +67,30 @@ func BuildApplicationNodeMetadata(paddle Paddle, existingMetadata configs.Applic
 	}
 	return metadata, nil
 }
+
+// DiffApplicationNodeMetadata compares the application nodes present in the CCJ with the provided application node
+// metadata. Application nodes that are missing from the metadata are returned with ~~FIXME~~ values that need to be
+// filled in, and the xnames of metadata entries that no longer match an application node in the CCJ are returned as
+// stale.
+func DiffApplicationNodeMetadata(paddle Paddle, metadata configs.ApplicationNodeMetadataMap, nidAssignment configs.NIDAssignment) (missing configs.ApplicationNodeMetadataMap, stale []string, err error) {
+	expectedMetadata, err := BuildApplicationNodeMetadata(paddle, metadata, nidAssignment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	missing = configs.ApplicationNodeMetadataMap{}
+	for xname, expected := range expectedMetadata {
+		if _, exists := metadata[xname]; !exists {
+			missing[xname] = expected
+		}
+	}
+
+	for xname := range metadata {
+		if _, exists := expectedMetadata[xname]; !exists {
+			stale = append(stale, xname)
+		}
+	}
+	sort.Strings(stale)
+
+	return missing, stale, nil
+}
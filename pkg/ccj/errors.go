@@ -0,0 +1,67 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package ccj
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrDuplicateXname = errors.New("duplicate xname")
+	ErrUnknownCabinet = errors.New("unknown cabinet")
+)
+
+// HardwareError is returned when the expected SLS hardware cannot be built from the CCJ. It contains the xname of the
+// hardware and the CANU common name of the topology node it came from, when known.
+type HardwareError struct {
+	Xname      string
+	CommonName string
+	Err        error
+}
+
+func (e *HardwareError) Error() string {
+	switch {
+	case e.Xname != "" && e.CommonName != "":
+		return fmt.Sprintf("%s (%s): %v", e.Xname, e.CommonName, e.Err)
+	case e.Xname != "":
+		return fmt.Sprintf("%s: %v", e.Xname, e.Err)
+	default:
+		return fmt.Sprintf("%s: %v", e.CommonName, e.Err)
+	}
+}
+
+func (e *HardwareError) Unwrap() error {
+	return e.Err
+}
+
+// UnknownArchitectureError is returned when a topology node in the CCJ has an architecture that this tool does not
+// know how to represent in SLS.
+type UnknownArchitectureError struct {
+	CommonName   string
+	Architecture string
+}
+
+func (e *UnknownArchitectureError) Error() string {
+	return fmt.Sprintf("unknown architecture type %s for CANU common name %s", e.Architecture, e.CommonName)
+}
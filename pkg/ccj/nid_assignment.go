@@ -0,0 +1,133 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package ccj
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/sls"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/Cray-HPE/hms-xname/xnames"
+)
+
+func extractLastNumber(numberRaw string) (int, error) {
+	matches := regexp.MustCompile(`(\d+)\D*$`).FindStringSubmatch(strings.ToLower(numberRaw))
+
+	if len(matches) < 2 {
+		return 0, fmt.Errorf("unexpected number of matches %d expected 2", len(matches))
+	}
+
+	return strconv.Atoi(matches[1])
+}
+
+// DetermineComputeNID will determine the NID of a compute node in the CCJ using the given NID assignment.
+func DetermineComputeNID(topologyNode TopologyNode, nidAssignment configs.NIDAssignment) (int, error) {
+	var nid int
+	switch nidAssignment.Mode {
+	case "", configs.NIDAssignmentModeCommonName:
+		var err error
+		nid, err = extractNumber(topologyNode.CommonName)
+		if err != nil {
+			return 0, fmt.Errorf("unable to extract NID from common name (%s) due to: %w", topologyNode.CommonName, err)
+		}
+	case configs.NIDAssignmentModeCabinetOffset:
+		cabinetOrdinal, err := extractNumber(topologyNode.Location.Rack)
+		if err != nil {
+			return 0, fmt.Errorf("unable to extract cabinet ordinal due to: %w", err)
+		}
+		cabinet := xnames.Cabinet{Cabinet: cabinetOrdinal}
+
+		baseNID, ok := nidAssignment.CabinetBaseNIDs[cabinet.String()]
+		if !ok {
+			return 0, fmt.Errorf("no base NID defined for cabinet (%s) containing compute node (%s)", cabinet.String(), topologyNode.CommonName)
+		}
+
+		offset, err := extractLastNumber(topologyNode.CommonName)
+		if err != nil {
+			return 0, fmt.Errorf("unable to extract NID offset from common name (%s) due to: %w", topologyNode.CommonName, err)
+		}
+
+		nid = baseNID + offset
+	case configs.NIDAssignmentModeExplicit:
+		var ok bool
+		nid, ok = nidAssignment.NIDs[topologyNode.CommonName]
+		if !ok {
+			return 0, fmt.Errorf("no NID defined for compute node (%s)", topologyNode.CommonName)
+		}
+	default:
+		return 0, fmt.Errorf("unknown nid assignment mode (%s)", nidAssignment.Mode)
+	}
+
+	if nid <= 0 {
+		return 0, fmt.Errorf("compute node (%s) has an invalid NID (%d)", topologyNode.CommonName, nid)
+	}
+
+	return nid, nil
+}
+
+// ValidateComputeNIDs will verify that the NIDs of the nodes in the expected hardware state are unique, and do not
+// collide with NIDs used by other nodes in the current hardware state.
+func ValidateComputeNIDs(expectedHardware, currentHardware map[string]sls_common.GenericHardware) error {
+	expectedNIDs, err := sls.NodeNIDs(expectedHardware)
+	if err != nil {
+		return err
+	}
+
+	currentNIDs, err := sls.NodeNIDs(currentHardware)
+	if err != nil {
+		return err
+	}
+
+	// Sort the NIDs so the reported problems are deterministic
+	var nids []int
+	for nid := range expectedNIDs {
+		nids = append(nids, nid)
+	}
+	sort.Ints(nids)
+
+	var problems []string
+	for _, nid := range nids {
+		xnames := expectedNIDs[nid]
+		if len(xnames) > 1 {
+			problems = append(problems, fmt.Sprintf("NID %d is assigned to multiple nodes (%s)", nid, strings.Join(xnames, ",")))
+			continue
+		}
+
+		for _, currentXname := range currentNIDs[nid] {
+			if currentXname != xnames[0] {
+				problems = append(problems, fmt.Sprintf("NID %d assigned to %s is already used by %s", nid, xnames[0], currentXname))
+			}
+		}
+	}
+
+	if len(problems) != 0 {
+		return fmt.Errorf("found NID conflicts: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package ccj
+
+import (
+	"testing"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+)
+
+type NIDAssignmentTestSuite struct {
+	suite.Suite
+}
+
+func (suite *NIDAssignmentTestSuite) computeNode(commonName, rack string) TopologyNode {
+	return TopologyNode{
+		CommonName:   commonName,
+		ID:           47,
+		Architecture: "river_compute_node",
+		Model:        "river_compute_node",
+		Type:         "node",
+		Vendor:       "none",
+		Location: Location{
+			Rack:      rack,
+			Elevation: "u26",
+		},
+	}
+}
+
+func (suite *NIDAssignmentTestSuite) TestCommonName() {
+	nid, err := DetermineComputeNID(suite.computeNode("cn003", "x3000"), configs.NIDAssignment{})
+	suite.NoError(err)
+	suite.Equal(3, nid)
+}
+
+func (suite *NIDAssignmentTestSuite) TestCommonName_NoNumber() {
+	_, err := DetermineComputeNID(suite.computeNode("cn", "x3000"), configs.NIDAssignment{})
+	suite.EqualError(err, "unable to extract NID from common name (cn) due to: unexpected number of matches 0 expected 2")
+}
+
+func (suite *NIDAssignmentTestSuite) TestCabinetOffset() {
+	nidAssignment := configs.NIDAssignment{
+		Mode: configs.NIDAssignmentModeCabinetOffset,
+		CabinetBaseNIDs: map[string]int{
+			"x3000": 1000,
+			"x3001": 2000,
+		},
+	}
+
+	nid, err := DetermineComputeNID(suite.computeNode("cn-a-12", "x3001"), nidAssignment)
+	suite.NoError(err)
+	suite.Equal(2012, nid)
+}
+
+func (suite *NIDAssignmentTestSuite) TestCabinetOffset_MissingCabinet() {
+	nidAssignment := configs.NIDAssignment{
+		Mode: configs.NIDAssignmentModeCabinetOffset,
+		CabinetBaseNIDs: map[string]int{
+			"x3000": 1000,
+		},
+	}
+
+	_, err := DetermineComputeNID(suite.computeNode("cn-a-12", "x3001"), nidAssignment)
+	suite.EqualError(err, "no base NID defined for cabinet (x3001) containing compute node (cn-a-12)")
+}
+
+func (suite *NIDAssignmentTestSuite) TestExplicit() {
+	nidAssignment := configs.NIDAssignment{
+		Mode: configs.NIDAssignmentModeExplicit,
+		NIDs: map[string]int{
+			"cn-a-twelve": 42,
+		},
+	}
+
+	nid, err := DetermineComputeNID(suite.computeNode("cn-a-twelve", "x3000"), nidAssignment)
+	suite.NoError(err)
+	suite.Equal(42, nid)
+
+	_, err = DetermineComputeNID(suite.computeNode("cn-b-one", "x3000"), nidAssignment)
+	suite.EqualError(err, "no NID defined for compute node (cn-b-one)")
+}
+
+func (suite *NIDAssignmentTestSuite) TestAliasFormat() {
+	topologyNode := suite.computeNode("cn003", "x3000")
+
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{AliasFormat: "cn%04d"})
+	suite.NoError(err)
+	suite.Equal([]string{"cn0003"}, extraProperties.Aliases)
+}
+
+func (suite *NIDAssignmentTestSuite) TestValidateComputeNIDs() {
+	expectedHardware := map[string]sls_common.GenericHardware{
+		"x3000c0s25b1n0": sls_common.NewGenericHardware("x3000c0s25b1n0", sls_common.ClassRiver, sls_common.ComptypeNode{
+			Role: "Compute", NID: 1, Aliases: []string{"nid000001"},
+		}),
+		"x3000c0s25b2n0": sls_common.NewGenericHardware("x3000c0s25b2n0", sls_common.ClassRiver, sls_common.ComptypeNode{
+			Role: "Compute", NID: 2, Aliases: []string{"nid000002"},
+		}),
+	}
+
+	currentHardware := map[string]sls_common.GenericHardware{
+		"x3000c0s25b1n0": {
+			Xname: "x3000c0s25b1n0",
+			ExtraPropertiesRaw: map[string]interface{}{
+				"Role": "Compute", "NID": 1, "Aliases": []string{"nid000001"},
+			},
+		},
+	}
+
+	suite.NoError(ValidateComputeNIDs(expectedHardware, currentHardware))
+}
+
+func (suite *NIDAssignmentTestSuite) TestValidateComputeNIDs_Conflicts() {
+	expectedHardware := map[string]sls_common.GenericHardware{
+		"x3000c0s25b1n0": sls_common.NewGenericHardware("x3000c0s25b1n0", sls_common.ClassRiver, sls_common.ComptypeNode{
+			Role: "Compute", NID: 1, Aliases: []string{"nid000001"},
+		}),
+		"x3000c0s25b2n0": sls_common.NewGenericHardware("x3000c0s25b2n0", sls_common.ClassRiver, sls_common.ComptypeNode{
+			Role: "Compute", NID: 1, Aliases: []string{"nid000001"},
+		}),
+		"x3000c0s25b3n0": sls_common.NewGenericHardware("x3000c0s25b3n0", sls_common.ClassRiver, sls_common.ComptypeNode{
+			Role: "Compute", NID: 1000, Aliases: []string{"nid001000"},
+		}),
+	}
+
+	currentHardware := map[string]sls_common.GenericHardware{
+		"x1000c0s0b0n0": {
+			Xname: "x1000c0s0b0n0",
+			Class: sls_common.ClassMountain,
+			ExtraPropertiesRaw: map[string]interface{}{
+				"Role": "Compute", "NID": 1000, "Aliases": []string{"nid001000"},
+			},
+		},
+	}
+
+	err := ValidateComputeNIDs(expectedHardware, currentHardware)
+	suite.EqualError(err, "found NID conflicts: NID 1 is assigned to multiple nodes (x3000c0s25b1n0,x3000c0s25b2n0); NID 1000 assigned to x3000c0s25b3n0 is already used by x1000c0s0b0n0")
+}
+
+func TestNIDAssignmentTestSuite(t *testing.T) {
+	suite.Run(t, new(NIDAssignmentTestSuite))
+}
@@ -23,9 +23,11 @@
 package ccj
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -58,18 +60,22 @@ func extractNumber(numberRaw string) (int, error) {
 	return number, nil
 }
 
-func BuildExpectedHardwareState(paddle Paddle, cabinetLookup configs.CabinetLookup, applicationNodeMetadata configs.ApplicationNodeMetadataMap, switchAliasesOverrides map[string][]string, ignoreUnknownCANUHardwareArchitectures bool) (sls_common.SLSState, error) {
+// BuildExpectedHardwareState will build the SLS hardware expected to be in the system from the CCJ. Errors about
+// specific hardware are returned as a *HardwareError.
+func BuildExpectedHardwareState(paddle Paddle, cabinetLookup configs.CabinetLookup, applicationNodeMetadata configs.ApplicationNodeMetadataMap, nidAssignment configs.NIDAssignment, switchAliasesOverrides map[string][]string, ignoreUnknownCANUHardwareArchitectures bool) (sls_common.SLSState, []DeviceWithoutHMNConnection, error) {
 	// Iterate over the paddle file to build of SLS data
 	allHardware := map[string]sls_common.GenericHardware{}
+	var devicesWithoutHMNConnection []DeviceWithoutHMNConnection
 	for _, topologyNode := range paddle.Topology {
 		//
 		// Build the SLS hardware representation
 		//
-		hardware, err := BuildSLSHardware(topologyNode, paddle, cabinetLookup, applicationNodeMetadata, switchAliasesOverrides)
-		if err != nil && ignoreUnknownCANUHardwareArchitectures && strings.Contains(err.Error(), "unknown architecture type") {
+		hardware, err := BuildSLSHardware(topologyNode, paddle, cabinetLookup, applicationNodeMetadata, nidAssignment, switchAliasesOverrides)
+		var unknownArchitectureErr *UnknownArchitectureError
+		if errors.As(err, &unknownArchitectureErr) && ignoreUnknownCANUHardwareArchitectures {
 			log.Printf("WARNING %s", err.Error())
 		} else if err != nil {
-			log.Fatalf("Error %v", err)
+			return sls_common.SLSState{}, nil, &HardwareError{CommonName: topologyNode.CommonName, Err: err}
 		}
 
 		// Ignore empty hardware
@@ -81,19 +87,18 @@ func BuildExpectedHardwareState(paddle Paddle, cabinetLookup configs.CabinetLook
 		if strings.HasPrefix(hardware.Xname, "x") {
 			cabinetXname, err := csi.CabinetForXname(hardware.Xname)
 			if err != nil {
-				panic(err)
+				return sls_common.SLSState{}, nil, &HardwareError{Xname: hardware.Xname, CommonName: topologyNode.CommonName, Err: err}
 			}
 
 			if !cabinetLookup.CabinetExists(cabinetXname) {
-				err := fmt.Errorf("unknown cabinet (%s)", cabinetXname)
-				panic(err)
+				return sls_common.SLSState{}, nil, &HardwareError{Xname: hardware.Xname, CommonName: topologyNode.CommonName,
+					Err: fmt.Errorf("%w (%s)", ErrUnknownCabinet, cabinetXname)}
 			}
 		}
 
 		// Verify new hardware
 		if _, present := allHardware[hardware.Xname]; present {
-			err := fmt.Errorf("found duplicate xname %v", hardware.Xname)
-			panic(err)
+			return sls_common.SLSState{}, nil, &HardwareError{Xname: hardware.Xname, CommonName: topologyNode.CommonName, Err: ErrDuplicateXname}
 		}
 
 		allHardware[hardware.Xname] = hardware
@@ -109,30 +114,41 @@ func BuildExpectedHardwareState(paddle Paddle, cabinetLookup configs.CabinetLook
 		// Build the MgmtSwitchConnector for the hardware
 		//
 
-		mgmtSwtichConnector, err := BuildSLSMgmtSwitchConnector(hardware, topologyNode, paddle)
+		mgmtSwtichConnectors, err := BuildSLSMgmtSwitchConnectors(hardware, topologyNode, paddle)
 		if err != nil {
-			panic(err)
+			return sls_common.SLSState{}, nil, &HardwareError{Xname: hardware.Xname, CommonName: topologyNode.CommonName,
+				Err: fmt.Errorf("unable to build MgmtSwitchConnectors: %w", err)}
 		}
 
-		// Ignore empty mgmtSwtichConnectors
-		if mgmtSwtichConnector.Xname == "" {
-			continue
+		// Keep track of hardware that should be connected to the HMN, but is not.
+		if len(mgmtSwtichConnectors) == 0 && requiresMgmtSwitchConnector(hardware) {
+			devicesWithoutHMNConnection = append(devicesWithoutHMNConnection, DeviceWithoutHMNConnection{
+				Xname:        hardware.Xname,
+				CommonName:   topologyNode.CommonName,
+				Architecture: topologyNode.Architecture,
+			})
 		}
 
-		if _, present := allHardware[mgmtSwtichConnector.Xname]; present {
-			err := fmt.Errorf("found duplicate xname %v", mgmtSwtichConnector.Xname)
-			panic(err)
-		}
+		for _, mgmtSwtichConnector := range mgmtSwtichConnectors {
+			if _, present := allHardware[mgmtSwtichConnector.Xname]; present {
+				return sls_common.SLSState{}, nil, &HardwareError{Xname: mgmtSwtichConnector.Xname, CommonName: topologyNode.CommonName, Err: ErrDuplicateXname}
+			}
 
-		allHardware[mgmtSwtichConnector.Xname] = mgmtSwtichConnector
+			allHardware[mgmtSwtichConnector.Xname] = mgmtSwtichConnector
+		}
 	}
 
+	// Sort the devices without a HMN connection to have a deterministic order
+	sort.Slice(devicesWithoutHMNConnection, func(i, j int) bool {
+		return devicesWithoutHMNConnection[i].Xname < devicesWithoutHMNConnection[j].Xname
+	})
+
 	// Generate Cabinet Objects
 	for cabinetKind, cabinets := range cabinetLookup {
 		for _, cabinet := range cabinets {
 			class, err := cabinetKind.Class()
 			if err != nil {
-				panic(err)
+				return sls_common.SLSState{}, nil, &HardwareError{Xname: cabinet, Err: err}
 			}
 
 			extraProperties := sls_common.ComptypeCabinet{
@@ -147,8 +163,7 @@ func BuildExpectedHardwareState(paddle Paddle, cabinetLookup configs.CabinetLook
 
 			// Verify new hardware
 			if _, present := allHardware[hardware.Xname]; present {
-				err := fmt.Errorf("found duplicate xname %v", hardware.Xname)
-				panic(err)
+				return sls_common.SLSState{}, nil, &HardwareError{Xname: hardware.Xname, Err: ErrDuplicateXname}
 			}
 
 			allHardware[hardware.Xname] = hardware
@@ -158,10 +173,10 @@ func BuildExpectedHardwareState(paddle Paddle, cabinetLookup configs.CabinetLook
 	// Build up and the SLS state
 	return sls_common.SLSState{
 		Hardware: allHardware,
-	}, nil
+	}, devicesWithoutHMNConnection, nil
 }
 
-func BuildSLSHardware(topologyNode TopologyNode, paddle Paddle, cabinetLookup configs.CabinetLookup, applicationNodeMetadata configs.ApplicationNodeMetadataMap, switchAliasesOverrides map[string][]string) (sls_common.GenericHardware, error) {
+func BuildSLSHardware(topologyNode TopologyNode, paddle Paddle, cabinetLookup configs.CabinetLookup, applicationNodeMetadata configs.ApplicationNodeMetadataMap, nidAssignment configs.NIDAssignment, switchAliasesOverrides map[string][]string) (sls_common.GenericHardware, error) {
 	// TODO use CANU files for lookup
 	// ALso look at using type
 	switch topologyNode.Architecture {
@@ -201,11 +216,11 @@ func BuildSLSHardware(topologyNode TopologyNode, paddle Paddle, cabinetLookup co
 		// of some sort.
 		if topologyNode.Type == "node" || topologyNode.Type == "server" {
 			// All node architecture needs to go through this function
-			return buildSLSNode(topologyNode, paddle, applicationNodeMetadata)
+			return buildSLSNode(topologyNode, paddle, applicationNodeMetadata, nidAssignment)
 		}
 	}
 
-	return sls_common.GenericHardware{}, fmt.Errorf("unknown architecture type %s for CANU common name %s", topologyNode.Architecture, topologyNode.CommonName)
+	return sls_common.GenericHardware{}, &UnknownArchitectureError{CommonName: topologyNode.CommonName, Architecture: topologyNode.Architecture}
 }
 
 func buildSLSPDUController(location Location) (sls_common.GenericHardware, error) {
@@ -276,10 +291,11 @@ func buildSLSCMC(location Location) (sls_common.GenericHardware, error) {
 }
 
 // BuildNodeExtraProperties will attempt to build up all of the known extra properties form a Node present in a CCJ.
+// The NID and alias of compute nodes are determined by the given NID assignment.
 // Limiitations the following information is not populated:
 // - Management NCN NID
 // - Application Node Subrole and Alias
-func BuildNodeExtraProperties(topologyNode TopologyNode) (extraProperties sls_common.ComptypeNode, err error) {
+func BuildNodeExtraProperties(topologyNode TopologyNode, nidAssignment configs.NIDAssignment) (extraProperties sls_common.ComptypeNode, err error) {
 	if topologyNode.Type != "server" && topologyNode.Type != "node" {
 		return sls_common.ComptypeNode{}, fmt.Errorf("unexpected topology node type (%s) expected (server or node)", topologyNode.Type)
 	}
@@ -300,14 +316,14 @@ func BuildNodeExtraProperties(topologyNode TopologyNode) (extraProperties sls_co
 		extraProperties.Aliases = []string{topologyNode.CommonName}
 	} else if strings.HasPrefix(topologyNode.CommonName, "cn") {
 		extraProperties.Role = "Compute"
-		extraProperties.NID, err = extractNumber(topologyNode.CommonName)
+		extraProperties.NID, err = DetermineComputeNID(topologyNode, nidAssignment)
 		if err != nil {
-			return sls_common.ComptypeNode{}, fmt.Errorf("unable to extract NID from common name (%s) due to: %w", topologyNode.CommonName, err)
+			return sls_common.ComptypeNode{}, err
 		}
 
 		// The CANU common name is different the compute node aliases that are present in SLS
 		extraProperties.Aliases = []string{
-			fmt.Sprintf("nid%06d", extraProperties.NID),
+			nidAssignment.Alias(extraProperties.NID),
 		}
 
 	} else {
@@ -320,7 +336,7 @@ func BuildNodeExtraProperties(topologyNode TopologyNode) (extraProperties sls_co
 	return extraProperties, nil
 }
 
-func BuildNodeXname(topologyNode TopologyNode, paddle Paddle, extraProperties sls_common.ComptypeNode) (xnames.Node, error) {
+func BuildNodeXname(topologyNode TopologyNode, paddle Paddle, extraProperties sls_common.ComptypeNode, applicationNodeMetadata configs.ApplicationNodeMetadataMap) (xnames.Node, error) {
 	if topologyNode.Type != "server" && topologyNode.Type != "node" {
 		return xnames.Node{}, fmt.Errorf("unexpected topology node type (%s) expected (server or node)", topologyNode.Type)
 	}
@@ -355,11 +371,13 @@ func BuildNodeXname(topologyNode TopologyNode, paddle Paddle, extraProperties sl
 		// 	return sls_common.GenericHardware{}, fmt.Errorf("unable to find parent topology node with common name (%v)", topologyNode.Location.Parent)
 		// }
 		var cmc TopologyNode
+		var cmcPort Port
 		if cmcPorts := topologyNode.FindPorts("cmc"); len(cmcPorts) == 1 {
 			var ok bool
-			cmc, ok = paddle.FindNodeByID(cmcPorts[0].DestNodeID)
+			cmcPort = cmcPorts[0]
+			cmc, ok = paddle.FindNodeByID(cmcPort.DestNodeID)
 			if !ok {
-				return xnames.Node{}, fmt.Errorf("unable to find parent topology node with id (%v)", cmcPorts[0].DestNodeID)
+				return xnames.Node{}, fmt.Errorf("unable to find parent topology node with id (%v)", cmcPort.DestNodeID)
 			}
 		} else {
 			return xnames.Node{}, fmt.Errorf("unexpected number of 'cmc' ports found (%v) expected 1", len(cmcPorts))
@@ -379,14 +397,19 @@ func BuildNodeXname(topologyNode TopologyNode, paddle Paddle, extraProperties sl
 			return xnames.Node{}, fmt.Errorf("unable to extract rack U ordinal from parent topology node due to: %w", err)
 		}
 
-		// Calculate the BMC ordinal, which is derived from its NID.
-		if extraProperties.Role != "Compute" {
-			return xnames.Node{}, fmt.Errorf("calculating BMC ordinal for a dense quad node chassis for a non compute node (%v) which is currently not supported", extraProperties.Role)
-		}
-		if extraProperties.NID == 0 {
-			return xnames.Node{}, fmt.Errorf("found compute node with a NID of 0")
+		if extraProperties.Role == "Compute" {
+			// Calculate the BMC ordinal, which is derived from its NID.
+			if extraProperties.NID == 0 {
+				return xnames.Node{}, fmt.Errorf("found compute node with a NID of 0")
+			}
+			bmcOrdinal = ((extraProperties.NID - 1) % 4) + 1
+		} else {
+			// Non compute nodes like UANs and gateways do not have a NID, so the BMC ordinal needs to come from somewhere else.
+			bmcOrdinal, err = determineDenseChassisBMCOrdinal(topologyNode, cmcPort, applicationNodeMetadata)
+			if err != nil {
+				return xnames.Node{}, err
+			}
 		}
-		bmcOrdinal = ((extraProperties.NID - 1) % 4) + 1
 	} else if strings.ToLower(topologyNode.Location.SubLocation) == "l" {
 		// This is the left side node in a dual node chassis
 		bmcOrdinal = 1
@@ -406,15 +429,39 @@ func BuildNodeXname(topologyNode TopologyNode, paddle Paddle, extraProperties sl
 	return xname, nil
 }
 
-func buildSLSNode(topologyNode TopologyNode, paddle Paddle, applicationNodeMetadata configs.ApplicationNodeMetadataMap) (sls_common.GenericHardware, error) {
+// determineDenseChassisBMCOrdinal will determine the BMC ordinal of a non compute node within a dense quad node chassis.
+// In order of precedence the BMC ordinal is taken from an explicit ordinal in the application node metadata, a numeric
+// sub-location, and lastly the CMC port that the node is connected to.
+func determineDenseChassisBMCOrdinal(topologyNode TopologyNode, cmcPort Port, applicationNodeMetadata configs.ApplicationNodeMetadataMap) (int, error) {
+	var bmcOrdinal int
+	var source string
+	if _, metadata, ok := applicationNodeMetadata.FindByCANUCommonName(topologyNode.CommonName); ok && metadata.BMCOrdinal != nil {
+		bmcOrdinal = *metadata.BMCOrdinal
+		source = "application node metadata"
+	} else if subLocationOrdinal, err := extractNumber(topologyNode.Location.SubLocation); err == nil {
+		bmcOrdinal = subLocationOrdinal
+		source = "sub-location"
+	} else {
+		bmcOrdinal = cmcPort.DestPort
+		source = "CMC port"
+	}
+
+	if bmcOrdinal < 1 || 4 < bmcOrdinal {
+		return 0, fmt.Errorf("invalid BMC ordinal (%d) from the %s for %s in a dense quad node chassis, expected 1 through 4", bmcOrdinal, source, topologyNode.CommonName)
+	}
+
+	return bmcOrdinal, nil
+}
+
+func buildSLSNode(topologyNode TopologyNode, paddle Paddle, applicationNodeMetadata configs.ApplicationNodeMetadataMap, nidAssignment configs.NIDAssignment) (sls_common.GenericHardware, error) {
 	// Build up the nodes ExtraProperties
-	extraProperties, err := BuildNodeExtraProperties(topologyNode)
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, nidAssignment)
 	if err != nil {
 		return sls_common.GenericHardware{}, fmt.Errorf("unable to build node extra properties: %w", err)
 	}
 
 	// Build the xname!
-	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties)
+	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties, applicationNodeMetadata)
 	if err != nil {
 		return sls_common.GenericHardware{}, fmt.Errorf("unable to build node xname: %w", err)
 	}
@@ -585,14 +632,30 @@ func buildSLSCDUMgmtSwitch(topologyNode TopologyNode, switchAliasesOverrides map
 	return sls_common.NewGenericHardware(xname.String(), sls_common.ClassMountain, extraProperties), nil
 }
 
-func BuildSLSMgmtSwitchConnector(hardware sls_common.GenericHardware, topologyNode TopologyNode, paddle Paddle) (sls_common.GenericHardware, error) {
+// DeviceWithoutHMNConnection describes hardware from the CCJ that is expected to be connected to the HMN, but does not
+// have any connections to a management switch.
+type DeviceWithoutHMNConnection struct {
+	Xname        string
+	CommonName   string
+	Architecture string
+}
+
+// requiresMgmtSwitchConnector determines if the given hardware is expected to have its BMC or controller connected to the HMN.
+func requiresMgmtSwitchConnector(hardware sls_common.GenericHardware) bool {
 	hmsTypesToIgnore := map[xnametypes.HMSType]bool{
 		xnametypes.MgmtHLSwitch:  true,
 		xnametypes.MgmtSwitch:    true,
 		xnametypes.CDUMgmtSwitch: true,
 	}
-	if hmsTypesToIgnore[xnametypes.GetHMSType(hardware.Xname)] || hardware.Class != sls_common.ClassRiver {
-		return sls_common.GenericHardware{}, nil
+
+	return !hmsTypesToIgnore[xnametypes.GetHMSType(hardware.Xname)] && hardware.Class == sls_common.ClassRiver
+}
+
+// BuildSLSMgmtSwitchConnectors will build a MgmtSwitchConnector for each connection the BMC or controller of the hardware
+// has to the HMN. Devices such as dual-homed BMCs or PDUs will have more than one MgmtSwitchConnector.
+func BuildSLSMgmtSwitchConnectors(hardware sls_common.GenericHardware, topologyNode TopologyNode, paddle Paddle) ([]sls_common.GenericHardware, error) {
+	if !requiresMgmtSwitchConnector(hardware) {
+		return nil, nil
 	}
 
 	//
@@ -607,22 +670,27 @@ func BuildSLSMgmtSwitchConnector(hardware sls_common.GenericHardware, topologyNo
 	}
 
 	//
-	// Figure out what switch port the BMC/Controller that is connected to the HMN
+	// Figure out what switch ports the BMC/Controller that is connected to the HMN
 	//
 	slot := "bmc" // By default lets assume bmc.
 	if topologyNode.Architecture == "slingshot_hsn_switch" {
 		slot = "mgmt"
 	}
 
-	destinationPorts := topologyNode.FindPorts(slot)
-	if len(destinationPorts) == 0 {
-		log.Printf("%s (%s) does not have a connection to the HMN\n", hardware.Xname, topologyNode.CommonName)
-		return sls_common.GenericHardware{}, nil
-	} else if len(destinationPorts) != 1 {
-		return sls_common.GenericHardware{}, fmt.Errorf("unexpected number of '%s' ports found (%v) expected 1", slot, len(destinationPorts))
+	var mgmtSwitchConnectors []sls_common.GenericHardware
+	for _, destinationPort := range topologyNode.FindPorts(slot) {
+		mgmtSwitchConnector, err := buildSLSMgmtSwitchConnector(destinationXname, destinationPort, paddle)
+		if err != nil {
+			return nil, err
+		}
+
+		mgmtSwitchConnectors = append(mgmtSwitchConnectors, mgmtSwitchConnector)
 	}
-	destinationPort := destinationPorts[0]
 
+	return mgmtSwitchConnectors, nil
+}
+
+func buildSLSMgmtSwitchConnector(destinationXname string, destinationPort Port, paddle Paddle) (sls_common.GenericHardware, error) {
 	destinationTopologyNode, ok := paddle.FindNodeByID(destinationPort.DestNodeID)
 	if !ok {
 		return sls_common.GenericHardware{}, fmt.Errorf("unable to find destination topology node referenced by port with id (%v)", destinationPort.DestNodeID)
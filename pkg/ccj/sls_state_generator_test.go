@@ -25,6 +25,7 @@ package ccj
 import (
 	"testing"
 
+	"github.com/Cray-HPE/cray-site-init/pkg/csi"
 	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
 	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
 	"github.com/Cray-HPE/hms-xname/xnames"
@@ -133,7 +134,7 @@ func (suite *SLSStateGeneratorTestSuite) TestNode_Compute() {
 		Topology: []TopologyNode{topologyNode, topologyNodeCMC},
 	}
 
-	hardware, err := buildSLSNode(topologyNode, paddle, nil)
+	hardware, err := buildSLSNode(topologyNode, paddle, nil, configs.NIDAssignment{})
 	suite.NoError(err)
 
 	expectedHardware := sls_common.NewGenericHardware("x3000c0s25b3n0", sls_common.ClassRiver, sls_common.ComptypeNode{
@@ -169,7 +170,7 @@ func (suite *SLSStateGeneratorTestSuite) TestNode_Application() {
 		Topology: []TopologyNode{topologyNode, topologyNode},
 	}
 
-	hardware, err := buildSLSNode(topologyNode, paddle, applicationNodeMetadata)
+	hardware, err := buildSLSNode(topologyNode, paddle, applicationNodeMetadata, configs.NIDAssignment{})
 	suite.NoError(err)
 
 	expectedHardware := sls_common.NewGenericHardware("x3000c0s15b0n0", sls_common.ClassRiver, sls_common.ComptypeNode{
@@ -441,6 +442,70 @@ func (suite *SLSStateGeneratorTestSuite) TestCDUMgmtSwitch_Dell_D_Prefix() {
 	suite.Equal(expectedHardware, hardware)
 }
 
+func (suite *SLSStateGeneratorTestSuite) buildExpectedHardwareState(topology ...TopologyNode) (sls_common.SLSState, error) {
+	cabinetLookup := configs.CabinetLookup{
+		csi.CabinetKindRiver: []string{"x3000"},
+	}
+
+	slsState, _, err := BuildExpectedHardwareState(Paddle{Topology: topology}, cabinetLookup, nil, configs.NIDAssignment{}, nil, false)
+	return slsState, err
+}
+
+func (suite *SLSStateGeneratorTestSuite) TestBuildExpectedHardwareState_PDU() {
+	slsState, err := suite.buildExpectedHardwareState(
+		TopologyNode{CommonName: "x3000p0", Architecture: "pdu", Location: Location{Rack: "x3000", Elevation: "p0"}},
+	)
+	suite.NoError(err)
+	suite.Contains(slsState.Hardware, "x3000")
+	suite.Contains(slsState.Hardware, "x3000m0")
+}
+
+func (suite *SLSStateGeneratorTestSuite) TestBuildExpectedHardwareState_DuplicateXname() {
+	_, err := suite.buildExpectedHardwareState(
+		TopologyNode{CommonName: "x3000p0", Architecture: "pdu", Location: Location{Rack: "x3000", Elevation: "p0"}},
+		TopologyNode{CommonName: "x3000p0-dup", Architecture: "pdu", Location: Location{Rack: "x3000", Elevation: "p0"}},
+	)
+	suite.EqualError(err, "x3000m0 (x3000p0-dup): duplicate xname")
+	suite.ErrorIs(err, ErrDuplicateXname)
+
+	var hardwareErr *HardwareError
+	suite.Require().ErrorAs(err, &hardwareErr)
+	suite.Equal("x3000m0", hardwareErr.Xname)
+	suite.Equal("x3000p0-dup", hardwareErr.CommonName)
+}
+
+func (suite *SLSStateGeneratorTestSuite) TestBuildExpectedHardwareState_UnknownCabinet() {
+	_, err := suite.buildExpectedHardwareState(
+		TopologyNode{CommonName: "x3001p0", Architecture: "pdu", Location: Location{Rack: "x3001", Elevation: "p0"}},
+	)
+	suite.EqualError(err, "x3001m0 (x3001p0): unknown cabinet (x3001)")
+	suite.ErrorIs(err, ErrUnknownCabinet)
+}
+
+func (suite *SLSStateGeneratorTestSuite) TestBuildExpectedHardwareState_UnknownArchitecture() {
+	_, err := suite.buildExpectedHardwareState(
+		TopologyNode{CommonName: "foo-001", Architecture: "foo", Type: "foo", Location: Location{Rack: "x3000", Elevation: "u01"}},
+	)
+	suite.EqualError(err, "foo-001: unknown architecture type foo for CANU common name foo-001")
+
+	var unknownArchitectureErr *UnknownArchitectureError
+	suite.Require().ErrorAs(err, &unknownArchitectureErr)
+	suite.Equal(UnknownArchitectureError{CommonName: "foo-001", Architecture: "foo"}, *unknownArchitectureErr)
+}
+
+func (suite *SLSStateGeneratorTestSuite) TestBuildExpectedHardwareState_IgnoreUnknownArchitecture() {
+	cabinetLookup := configs.CabinetLookup{
+		csi.CabinetKindRiver: []string{"x3000"},
+	}
+	paddle := Paddle{Topology: []TopologyNode{
+		{CommonName: "foo-001", Architecture: "foo", Type: "foo", Location: Location{Rack: "x3000", Elevation: "u01"}},
+	}}
+
+	slsState, _, err := BuildExpectedHardwareState(paddle, cabinetLookup, nil, configs.NIDAssignment{}, nil, true)
+	suite.NoError(err)
+	suite.Len(slsState.Hardware, 1)
+}
+
 func TestSLSStateGeneratorTestSuite(t *testing.T) {
 	suite.Run(t, new(SLSStateGeneratorTestSuite))
 }
@@ -463,7 +528,7 @@ func (suite *BuildNodeExtraPropertiesTestSuite) TestMaster() {
 		},
 	}
 
-	extraProperties, err := BuildNodeExtraProperties(topologyNode)
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{})
 	suite.NoError(err)
 
 	expectedExtraProperties := sls_common.ComptypeNode{
@@ -487,7 +552,7 @@ func (suite *BuildNodeExtraPropertiesTestSuite) TestWorker() {
 		},
 	}
 
-	extraProperties, err := BuildNodeExtraProperties(topologyNode)
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{})
 	suite.NoError(err)
 
 	expectedExtraProperties := sls_common.ComptypeNode{
@@ -512,7 +577,7 @@ func (suite *BuildNodeExtraPropertiesTestSuite) TestStorage() {
 		},
 	}
 
-	extraProperties, err := BuildNodeExtraProperties(topologyNode)
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{})
 	suite.NoError(err)
 
 	expectedExtraProperties := sls_common.ComptypeNode{
@@ -537,7 +602,7 @@ func (suite *BuildNodeExtraPropertiesTestSuite) TestCompute() {
 		},
 	}
 
-	extraProperties, err := BuildNodeExtraProperties(topologyNode)
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{})
 	suite.NoError(err)
 
 	expectedExtraProperties := sls_common.ComptypeNode{
@@ -562,7 +627,7 @@ func (suite *BuildNodeExtraPropertiesTestSuite) TestApplication() {
 		},
 	}
 
-	extraProperties, err := BuildNodeExtraProperties(topologyNode)
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{})
 	suite.NoError(err)
 
 	expectedExtraProperties := sls_common.ComptypeNode{
@@ -585,7 +650,7 @@ func (suite *BuildNodeExtraPropertiesTestSuite) TestInvalidHardware() {
 		},
 	}
 
-	_, err := BuildNodeExtraProperties(topologyNode)
+	_, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{})
 	suite.Errorf(err, "unexpected topology node type (pdu) expected (server or node)")
 }
 
@@ -621,7 +686,7 @@ func (suite *BuildNodeXnameTestSuite) TestSingleChassisNode() {
 		Topology: []TopologyNode{topologyNode},
 	}
 
-	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties)
+	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties, nil)
 	suite.NoError(err)
 
 	expectedXname := xnames.Node{
@@ -651,14 +716,14 @@ func (suite *BuildNodeXnameTestSuite) TestDualChassisNode_Left() {
 		},
 	}
 
-	extraProperties, err := BuildNodeExtraProperties(topologyNode)
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{})
 	suite.NoError(err)
 
 	paddle := Paddle{
 		Topology: []TopologyNode{topologyNode},
 	}
 
-	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties)
+	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties, nil)
 	suite.NoError(err)
 
 	expectedXname := xnames.Node{
@@ -689,14 +754,14 @@ func (suite *BuildNodeXnameTestSuite) TestDualChassisNode_Right() {
 		},
 	}
 
-	extraProperties, err := BuildNodeExtraProperties(topologyNode)
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{})
 	suite.NoError(err)
 
 	paddle := Paddle{
 		Topology: []TopologyNode{topologyNode},
 	}
 
-	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties)
+	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties, nil)
 	suite.NoError(err)
 
 	expectedXname := xnames.Node{
@@ -760,7 +825,124 @@ func (suite *BuildNodeXnameTestSuite) TestQuadChassisNode() {
 		Topology: []TopologyNode{topologyNode, topologyNodeCMC},
 	}
 
-	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties)
+	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties, nil)
+	suite.NoError(err)
+
+	expectedXname := xnames.Node{
+		Cabinet:       3000,
+		Chassis:       0,
+		ComputeModule: 25,
+		NodeBMC:       3,
+		Node:          0,
+	}
+
+	suite.Equal(expectedXname, xname)
+}
+
+func (suite *BuildNodeXnameTestSuite) quadChassisApplicationNodePaddle(subLocation string) Paddle {
+	topologyNode := TopologyNode{
+		CommonName:   "uan005",
+		ID:           48,
+		Architecture: "river_ncn_node_4_port",
+		Model:        "river_ncn_node_4_port",
+		Type:         "server",
+		Vendor:       "hpe",
+		Ports: []Port{
+			{
+				Port:       1,
+				Speed:      1,
+				Slot:       "cmc",
+				DestNodeID: 46,
+				DestPort:   2,
+				DestSlot:   "cmc",
+			},
+		},
+		Location: Location{
+			Rack:        "x3000",
+			Elevation:   "u26",
+			Parent:      "SubRack-001-CMC",
+			SubLocation: subLocation,
+		},
+	}
+
+	topologyNodeCMC := TopologyNode{
+		CommonName:   "SubRack001-CMC",
+		ID:           46,
+		Architecture: "subrack",
+		Model:        "subrack",
+		Type:         "subrack",
+		Vendor:       "none",
+		Location: Location{
+			Rack:      "x3000",
+			Elevation: "u25",
+		},
+	}
+
+	return Paddle{
+		Topology: []TopologyNode{topologyNode, topologyNodeCMC},
+	}
+}
+
+func (suite *BuildNodeXnameTestSuite) TestQuadChassisApplicationNode_CMCPort() {
+	paddle := suite.quadChassisApplicationNodePaddle("")
+	topologyNode := paddle.Topology[0]
+
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{})
+	suite.NoError(err)
+
+	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties, nil)
+	suite.NoError(err)
+
+	expectedXname := xnames.Node{
+		Cabinet:       3000,
+		Chassis:       0,
+		ComputeModule: 25,
+		NodeBMC:       2,
+		Node:          0,
+	}
+
+	suite.Equal(expectedXname, xname)
+}
+
+func (suite *BuildNodeXnameTestSuite) TestQuadChassisApplicationNode_SubLocation() {
+	paddle := suite.quadChassisApplicationNodePaddle("4")
+	topologyNode := paddle.Topology[0]
+
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{})
+	suite.NoError(err)
+
+	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties, nil)
+	suite.NoError(err)
+
+	expectedXname := xnames.Node{
+		Cabinet:       3000,
+		Chassis:       0,
+		ComputeModule: 25,
+		NodeBMC:       4,
+		Node:          0,
+	}
+
+	suite.Equal(expectedXname, xname)
+}
+
+func (suite *BuildNodeXnameTestSuite) TestQuadChassisApplicationNode_ExplicitOrdinal() {
+	paddle := suite.quadChassisApplicationNodePaddle("4")
+	topologyNode := paddle.Topology[0]
+
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{})
+	suite.NoError(err)
+
+	bmcOrdinal := 3
+	applicationNodeMetadata := configs.ApplicationNodeMetadataMap{
+		"x3000c0s25b3n0": {
+			CANUCommonName: "uan005",
+			SubRole:        "UAN",
+			Aliases:        []string{"uan05"},
+			BMCOrdinal:     &bmcOrdinal,
+		},
+	}
+
+	xname, err := BuildNodeXname(topologyNode, paddle, extraProperties, applicationNodeMetadata)
 	suite.NoError(err)
 
 	expectedXname := xnames.Node{
@@ -774,6 +956,17 @@ func (suite *BuildNodeXnameTestSuite) TestQuadChassisNode() {
 	suite.Equal(expectedXname, xname)
 }
 
+func (suite *BuildNodeXnameTestSuite) TestQuadChassisApplicationNode_InvalidOrdinal() {
+	paddle := suite.quadChassisApplicationNodePaddle("5")
+	topologyNode := paddle.Topology[0]
+
+	extraProperties, err := BuildNodeExtraProperties(topologyNode, configs.NIDAssignment{})
+	suite.NoError(err)
+
+	_, err = BuildNodeXname(topologyNode, paddle, extraProperties, nil)
+	suite.EqualError(err, "invalid BMC ordinal (5) from the sub-location for uan005 in a dense quad node chassis, expected 1 through 4")
+}
+
 func (suite *BuildNodeXnameTestSuite) TestInvalidHardware() {
 	topologyNode := TopologyNode{
 		CommonName:   "pdu-x3001-000",
@@ -792,7 +985,7 @@ func (suite *BuildNodeXnameTestSuite) TestInvalidHardware() {
 		Topology: []TopologyNode{topologyNode},
 	}
 
-	_, err := BuildNodeXname(topologyNode, paddle, sls_common.ComptypeNode{})
+	_, err := BuildNodeXname(topologyNode, paddle, sls_common.ComptypeNode{}, nil)
 	suite.Errorf(err, "unexpected topology node type (pdu) expected (server or node)")
 }
 
@@ -806,9 +999,9 @@ type BuildSLSMgmtSwitchConnectorTestSuite struct {
 
 func (suite *BuildSLSMgmtSwitchConnectorTestSuite) TestIgnore() {
 	for _, xname := range []string{"x3000c0w1", "x3000c0h1s1", "d0w1"} {
-		hardware, err := BuildSLSMgmtSwitchConnector(sls_common.NewGenericHardware(xname, sls_common.ClassRiver, nil), TopologyNode{}, Paddle{})
+		hardware, err := BuildSLSMgmtSwitchConnectors(sls_common.NewGenericHardware(xname, sls_common.ClassRiver, nil), TopologyNode{}, Paddle{})
 		suite.NoError(err)
-		suite.Equal(sls_common.GenericHardware{}, hardware)
+		suite.Empty(hardware)
 	}
 }
 
@@ -854,7 +1047,7 @@ func (suite *BuildSLSMgmtSwitchConnectorTestSuite) TestUnknownSwitchVendor() {
 		},
 	}
 
-	_, err := BuildSLSMgmtSwitchConnector(
+	_, err := BuildSLSMgmtSwitchConnectors(
 		sls_common.NewGenericHardware("x3000c0s16b0n0", sls_common.ClassRiver, nil),
 		paddle.Topology[0],
 		paddle,
@@ -920,7 +1113,7 @@ func (suite *BuildSLSMgmtSwitchConnectorTestSuite) TestNode_Aruba() {
 		},
 	}
 
-	mgmtSwitchConnector, err := BuildSLSMgmtSwitchConnector(
+	mgmtSwitchConnectors, err := BuildSLSMgmtSwitchConnectors(
 		sls_common.NewGenericHardware("x3000c0s16b0n0", sls_common.ClassRiver, nil),
 		paddle.Topology[0],
 		paddle,
@@ -932,7 +1125,7 @@ func (suite *BuildSLSMgmtSwitchConnectorTestSuite) TestNode_Aruba() {
 		NodeNics:   []string{"x3000c0s16b0"},
 	})
 
-	suite.Equal(expectedMgmtSwitchConnector, mgmtSwitchConnector)
+	suite.Equal([]sls_common.GenericHardware{expectedMgmtSwitchConnector}, mgmtSwitchConnectors)
 }
 
 func (suite *BuildSLSMgmtSwitchConnectorTestSuite) TestNode_Dell() {
@@ -977,7 +1170,7 @@ func (suite *BuildSLSMgmtSwitchConnectorTestSuite) TestNode_Dell() {
 		},
 	}
 
-	mgmtSwitchConnector, err := BuildSLSMgmtSwitchConnector(
+	mgmtSwitchConnectors, err := BuildSLSMgmtSwitchConnectors(
 		sls_common.NewGenericHardware("x3000c0s16b0n0", sls_common.ClassRiver, nil),
 		paddle.Topology[0],
 		paddle,
@@ -989,7 +1182,112 @@ func (suite *BuildSLSMgmtSwitchConnectorTestSuite) TestNode_Dell() {
 		NodeNics:   []string{"x3000c0s16b0"},
 	})
 
-	suite.Equal(expectedMgmtSwitchConnector, mgmtSwitchConnector)
+	suite.Equal([]sls_common.GenericHardware{expectedMgmtSwitchConnector}, mgmtSwitchConnectors)
+}
+
+func (suite *BuildSLSMgmtSwitchConnectorTestSuite) TestNode_MultipleBMCConnections() {
+	paddle := Paddle{
+		Topology: []TopologyNode{
+			// Node
+			{
+				CommonName:   "uan002",
+				ID:           20,
+				Architecture: "river_ncn_node_4_port",
+				Model:        "river_ncn_node_4_port",
+				Type:         "server",
+				Vendor:       "hpe",
+				Ports: []Port{
+					{
+						Port:       1,
+						Speed:      1,
+						Slot:       "bmc",
+						DestNodeID: 19,
+						DestPort:   41,
+					},
+					{
+						Port:       2,
+						Speed:      1,
+						Slot:       "bmc",
+						DestNodeID: 18,
+						DestPort:   41,
+					},
+				},
+				Location: Location{
+					Rack:      "x3000",
+					Elevation: "u16",
+				},
+			},
+
+			// Switches
+			{
+				CommonName:   "sw-leaf-bmc-001",
+				ID:           19,
+				Architecture: "river_bmc_leaf",
+				Model:        "6300M_JL762A",
+				Type:         "switch",
+				Vendor:       "aruba",
+				Location: Location{
+					Rack:      "x3000",
+					Elevation: "u31",
+				},
+			},
+			{
+				CommonName:   "sw-leaf-bmc-002",
+				ID:           18,
+				Architecture: "river_bmc_leaf",
+				Model:        "6300M_JL762A",
+				Type:         "switch",
+				Vendor:       "aruba",
+				Location: Location{
+					Rack:      "x3000",
+					Elevation: "u32",
+				},
+			},
+		},
+	}
+
+	mgmtSwitchConnectors, err := BuildSLSMgmtSwitchConnectors(
+		sls_common.NewGenericHardware("x3000c0s16b0n0", sls_common.ClassRiver, nil),
+		paddle.Topology[0],
+		paddle,
+	)
+	suite.NoError(err)
+
+	expectedMgmtSwitchConnectors := []sls_common.GenericHardware{
+		sls_common.NewGenericHardware("x3000c0w31j41", sls_common.ClassRiver, sls_common.ComptypeMgmtSwitchConnector{
+			VendorName: "1/1/41",
+			NodeNics:   []string{"x3000c0s16b0"},
+		}),
+		sls_common.NewGenericHardware("x3000c0w32j41", sls_common.ClassRiver, sls_common.ComptypeMgmtSwitchConnector{
+			VendorName: "1/1/41",
+			NodeNics:   []string{"x3000c0s16b0"},
+		}),
+	}
+
+	suite.Equal(expectedMgmtSwitchConnectors, mgmtSwitchConnectors)
+}
+
+func (suite *BuildSLSMgmtSwitchConnectorTestSuite) TestNode_NoBMCConnection() {
+	topologyNode := TopologyNode{
+		CommonName:   "uan002",
+		ID:           20,
+		Architecture: "river_ncn_node_4_port",
+		Model:        "river_ncn_node_4_port",
+		Type:         "server",
+		Vendor:       "hpe",
+		Location: Location{
+			Rack:      "x3000",
+			Elevation: "u16",
+		},
+	}
+
+	mgmtSwitchConnectors, err := BuildSLSMgmtSwitchConnectors(
+		sls_common.NewGenericHardware("x3000c0s16b0n0", sls_common.ClassRiver, nil),
+		topologyNode,
+		Paddle{Topology: []TopologyNode{topologyNode}},
+	)
+	suite.NoError(err)
+	suite.Empty(mgmtSwitchConnectors)
 }
 
 func TestBuildSLSMgmtSwitchConnector(t *testing.T) {
@@ -0,0 +1,147 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package configs
+
+import (
+	"fmt"
+
+	"inet.af/netaddr"
+)
+
+// SubnetAddressingPlan controls the size of new cabinet subnets allocated within a network, and how the addresses
+// within the subnet are laid out. Offsets are relative to the network address of the subnet, and the DHCP end
+// reserve is the number of addresses at the end of the subnet (including the broadcast address) left out of the DHCP
+// range. A zero value field uses the default value.
+type SubnetAddressingPlan struct {
+	PrefixLength    uint8  `yaml:"prefix_length,omitempty"`
+	GatewayOffset   uint32 `yaml:"gateway_offset,omitempty"`
+	DHCPStartOffset uint32 `yaml:"dhcp_start_offset,omitempty"`
+	DHCPEndReserve  uint32 `yaml:"dhcp_end_reserve,omitempty"`
+
+	// IPv6CIDR optionally makes the network dual-stack. New cabinet subnets are given an IPv6 prefix of
+	// IPv6PrefixLength carved out of it, and the IP6addr of new management switches is placed at the same offset
+	// within it as their IPv4 address is within the IPv4 network.
+	IPv6CIDR         string `yaml:"ipv6_cidr,omitempty"`
+	IPv6PrefixLength uint8  `yaml:"ipv6_prefix_length,omitempty"`
+}
+
+// DefaultIPv6PrefixLength is the prefix length of the IPv6 prefix given to new cabinet subnets in dual-stack networks.
+const DefaultIPv6PrefixLength = 64
+
+// DefaultSubnetAddressingPlan matches the cabinet subnets created by CSI, which are /22 subnets with the gateway
+// at the first usable address, and DHCP from 10 addresses into the subnet to the address before the broadcast.
+var DefaultSubnetAddressingPlan = SubnetAddressingPlan{
+	PrefixLength:    22,
+	GatewayOffset:   1,
+	DHCPStartOffset: 10,
+	DHCPEndReserve:  1,
+}
+
+// WithDefaults will fill in any unset fields with values from the default addressing plan.
+func (p SubnetAddressingPlan) WithDefaults() SubnetAddressingPlan {
+	if p.PrefixLength == 0 {
+		p.PrefixLength = DefaultSubnetAddressingPlan.PrefixLength
+	}
+	if p.GatewayOffset == 0 {
+		p.GatewayOffset = DefaultSubnetAddressingPlan.GatewayOffset
+	}
+	if p.DHCPStartOffset == 0 {
+		p.DHCPStartOffset = DefaultSubnetAddressingPlan.DHCPStartOffset
+	}
+	if p.DHCPEndReserve == 0 {
+		p.DHCPEndReserve = DefaultSubnetAddressingPlan.DHCPEndReserve
+	}
+	if p.IPv6CIDR != "" && p.IPv6PrefixLength == 0 {
+		p.IPv6PrefixLength = DefaultIPv6PrefixLength
+	}
+
+	return p
+}
+
+// Validate will verify the addressing plan produces subnets that fit within the given parent network CIDR, and that
+// the gateway and DHCP range fit within the subnet.
+func (p SubnetAddressingPlan) Validate(networkCIDR string) error {
+	network, err := netaddr.ParseIPPrefix(networkCIDR)
+	if err != nil {
+		return fmt.Errorf("failed to parse network CIDR (%s): %w", networkCIDR, err)
+	}
+	if !network.IP().Is4() {
+		return fmt.Errorf("network CIDR (%s) is not an IPv4 network", networkCIDR)
+	}
+
+	if p.PrefixLength < 16 || 30 < p.PrefixLength {
+		return fmt.Errorf("invalid subnet prefix length /%d, expected /16 through /30", p.PrefixLength)
+	}
+	if p.PrefixLength < network.Bits() {
+		return fmt.Errorf("subnet prefix length /%d is larger than the network CIDR (%s)", p.PrefixLength, networkCIDR)
+	}
+
+	// The number of addresses in the subnet, including the network and broadcast addresses
+	subnetSize := uint32(1) << (32 - p.PrefixLength)
+
+	if p.GatewayOffset < 1 || subnetSize-1 <= p.GatewayOffset {
+		return fmt.Errorf("gateway offset (%d) is outside of the usable addresses of a /%d subnet", p.GatewayOffset, p.PrefixLength)
+	}
+	if p.DHCPEndReserve < 1 || subnetSize <= p.DHCPEndReserve {
+		return fmt.Errorf("DHCP end reserve (%d) must be at least 1 and less than the size of a /%d subnet", p.DHCPEndReserve, p.PrefixLength)
+	}
+	if p.DHCPStartOffset <= p.GatewayOffset {
+		return fmt.Errorf("DHCP start offset (%d) must be after the gateway offset (%d)", p.DHCPStartOffset, p.GatewayOffset)
+	}
+	if subnetSize-p.DHCPEndReserve <= p.DHCPStartOffset {
+		return fmt.Errorf("DHCP start offset (%d) and end reserve (%d) leave no DHCP range in a /%d subnet", p.DHCPStartOffset, p.DHCPEndReserve, p.PrefixLength)
+	}
+
+	if p.IPv6CIDR != "" {
+		ipv6Network, err := netaddr.ParseIPPrefix(p.IPv6CIDR)
+		if err != nil {
+			return fmt.Errorf("failed to parse IPv6 CIDR (%s): %w", p.IPv6CIDR, err)
+		}
+		if !ipv6Network.IP().Is6() {
+			return fmt.Errorf("IPv6 CIDR (%s) is not an IPv6 network", p.IPv6CIDR)
+		}
+
+		if p.IPv6PrefixLength < 48 || 126 < p.IPv6PrefixLength {
+			return fmt.Errorf("invalid IPv6 prefix length /%d, expected /48 through /126", p.IPv6PrefixLength)
+		}
+		if p.IPv6PrefixLength < ipv6Network.Bits() {
+			return fmt.Errorf("IPv6 prefix length /%d is larger than the IPv6 CIDR (%s)", p.IPv6PrefixLength, p.IPv6CIDR)
+		}
+
+		// Every IPv4 address of the network needs a matching IPv6 address
+		if 128-int(ipv6Network.Bits()) < 32-int(network.Bits()) {
+			return fmt.Errorf("IPv6 CIDR (%s) is too small to hold the addresses of network CIDR (%s)", p.IPv6CIDR, networkCIDR)
+		}
+	}
+
+	return nil
+}
+
+// AddressingPlan contains the addressing plan for cabinet subnets of each network. The key is the SLS network name,
+// such as HMN_RVR or NMN_RVR.
+type AddressingPlan map[string]SubnetAddressingPlan
+
+// ForNetwork will retrieve the addressing plan for the given network, with unset values filled in with the defaults.
+func (ap AddressingPlan) ForNetwork(networkName string) SubnetAddressingPlan {
+	return ap[networkName].WithDefaults()
+}
@@ -0,0 +1,126 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package configs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AddressingPlanTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AddressingPlanTestSuite) TestForNetwork_Defaults() {
+	addressingPlan := AddressingPlan{
+		"HMN_RVR": {PrefixLength: 24},
+	}
+
+	suite.Equal(DefaultSubnetAddressingPlan, addressingPlan.ForNetwork("NMN_RVR"))
+	suite.Equal(SubnetAddressingPlan{
+		PrefixLength:    24,
+		GatewayOffset:   1,
+		DHCPStartOffset: 10,
+		DHCPEndReserve:  1,
+	}, addressingPlan.ForNetwork("HMN_RVR"))
+}
+
+func (suite *AddressingPlanTestSuite) TestValidate() {
+	suite.NoError(DefaultSubnetAddressingPlan.Validate("10.107.0.0/17"))
+	suite.NoError(SubnetAddressingPlan{PrefixLength: 24, GatewayOffset: 1, DHCPStartOffset: 50, DHCPEndReserve: 5}.Validate("10.107.0.0/17"))
+}
+
+func (suite *AddressingPlanTestSuite) TestValidate_InvalidNetworkCIDR() {
+	suite.EqualError(DefaultSubnetAddressingPlan.Validate("foo"), `failed to parse network CIDR (foo): netaddr.ParseIPPrefix("foo"): no '/'`)
+}
+
+func (suite *AddressingPlanTestSuite) TestValidate_SubnetLargerThanNetwork() {
+	err := SubnetAddressingPlan{PrefixLength: 22, GatewayOffset: 1, DHCPStartOffset: 10, DHCPEndReserve: 1}.Validate("10.107.0.0/23")
+	suite.EqualError(err, "subnet prefix length /22 is larger than the network CIDR (10.107.0.0/23)")
+}
+
+func (suite *AddressingPlanTestSuite) TestValidate_InvalidPrefixLength() {
+	err := SubnetAddressingPlan{PrefixLength: 31, GatewayOffset: 1, DHCPStartOffset: 10, DHCPEndReserve: 1}.Validate("10.107.0.0/17")
+	suite.EqualError(err, "invalid subnet prefix length /31, expected /16 through /30")
+}
+
+func (suite *AddressingPlanTestSuite) TestValidate_GatewayOutsideSubnet() {
+	err := SubnetAddressingPlan{PrefixLength: 28, GatewayOffset: 15, DHCPStartOffset: 16, DHCPEndReserve: 1}.Validate("10.107.0.0/17")
+	suite.EqualError(err, "gateway offset (15) is outside of the usable addresses of a /28 subnet")
+}
+
+func (suite *AddressingPlanTestSuite) TestValidate_DHCPStartBeforeGateway() {
+	err := SubnetAddressingPlan{PrefixLength: 24, GatewayOffset: 20, DHCPStartOffset: 10, DHCPEndReserve: 1}.Validate("10.107.0.0/17")
+	suite.EqualError(err, "DHCP start offset (10) must be after the gateway offset (20)")
+}
+
+func (suite *AddressingPlanTestSuite) TestValidate_NoDHCPRange() {
+	err := SubnetAddressingPlan{PrefixLength: 28, GatewayOffset: 1, DHCPStartOffset: 10, DHCPEndReserve: 6}.Validate("10.107.0.0/17")
+	suite.EqualError(err, "DHCP start offset (10) and end reserve (6) leave no DHCP range in a /28 subnet")
+}
+
+func (suite *AddressingPlanTestSuite) TestForNetwork_IPv6Defaults() {
+	addressingPlan := AddressingPlan{
+		"HMN_RVR": {IPv6CIDR: "fd00:0:0:100::/56"},
+	}
+
+	suite.Equal(uint8(64), addressingPlan.ForNetwork("HMN_RVR").IPv6PrefixLength)
+	suite.Equal(uint8(0), addressingPlan.ForNetwork("NMN_RVR").IPv6PrefixLength)
+}
+
+func (suite *AddressingPlanTestSuite) TestValidate_IPv6() {
+	addressingPlan := DefaultSubnetAddressingPlan
+	addressingPlan.IPv6CIDR = "fd00:0:0:100::/56"
+	addressingPlan.IPv6PrefixLength = 64
+
+	suite.NoError(addressingPlan.Validate("10.107.0.0/17"))
+}
+
+func (suite *AddressingPlanTestSuite) TestValidate_IPv6NotIPv6() {
+	addressingPlan := DefaultSubnetAddressingPlan
+	addressingPlan.IPv6CIDR = "10.108.0.0/17"
+	addressingPlan.IPv6PrefixLength = 64
+
+	suite.EqualError(addressingPlan.Validate("10.107.0.0/17"), "IPv6 CIDR (10.108.0.0/17) is not an IPv6 network")
+}
+
+func (suite *AddressingPlanTestSuite) TestValidate_IPv6PrefixLargerThanNetwork() {
+	addressingPlan := DefaultSubnetAddressingPlan
+	addressingPlan.IPv6CIDR = "fd00:0:0:100::/64"
+	addressingPlan.IPv6PrefixLength = 56
+
+	suite.EqualError(addressingPlan.Validate("10.107.0.0/17"), "IPv6 prefix length /56 is larger than the IPv6 CIDR (fd00:0:0:100::/64)")
+}
+
+func (suite *AddressingPlanTestSuite) TestValidate_IPv6TooSmall() {
+	addressingPlan := DefaultSubnetAddressingPlan
+	addressingPlan.IPv6CIDR = "fd00:0:0:100::/120"
+	addressingPlan.IPv6PrefixLength = 124
+
+	suite.EqualError(addressingPlan.Validate("10.107.0.0/17"), "IPv6 CIDR (fd00:0:0:100::/120) is too small to hold the addresses of network CIDR (10.107.0.0/17)")
+}
+
+func TestAddressingPlanTestSuite(t *testing.T) {
+	suite.Run(t, new(AddressingPlanTestSuite))
+}
@@ -0,0 +1,96 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package configs
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultApplicationNetworkSubnet is the subnet application nodes are given IP reservations in, when the subnet is not
+// specified.
+const DefaultApplicationNetworkSubnet = "bootstrap_dhcp"
+
+// ApplicationNetworkSubnet identifies a subnet within a SLS network that an application node needs an IP reservation in.
+type ApplicationNetworkSubnet struct {
+	Network string `yaml:"network"`
+	Subnet  string `yaml:"subnet,omitempty"`
+}
+
+// ApplicationNetworkPolicy contains the networks and subnets application nodes need IP reservations in. The key is the
+// HSM SubRole of the application node, such as UAN or Gateway. Application nodes with a SubRole not present in the
+// policy are not given any IP reservations.
+type ApplicationNetworkPolicy map[string][]ApplicationNetworkSubnet
+
+// DefaultApplicationNetworkPolicy gives UANs an IP reservation in the bootstrap_dhcp subnet of the CAN and CHN networks.
+var DefaultApplicationNetworkPolicy = ApplicationNetworkPolicy{
+	"UAN": {
+		{Network: "CAN", Subnet: DefaultApplicationNetworkSubnet},
+		{Network: "CHN", Subnet: DefaultApplicationNetworkSubnet},
+	},
+}
+
+// ForSubRole will retrieve the networks and subnets for application nodes with the given SubRole, with unset subnets
+// filled in with the default subnet.
+func (p ApplicationNetworkPolicy) ForSubRole(subRole string) []ApplicationNetworkSubnet {
+	var result []ApplicationNetworkSubnet
+	for _, networkSubnet := range p[subRole] {
+		if networkSubnet.Subnet == "" {
+			networkSubnet.Subnet = DefaultApplicationNetworkSubnet
+		}
+
+		result = append(result, networkSubnet)
+	}
+
+	return result
+}
+
+// Validate will verify every entry of the policy names a network, and that no SubRole lists the same network and
+// subnet more than once.
+func (p ApplicationNetworkPolicy) Validate() error {
+	var subRoles []string
+	for subRole := range p {
+		subRoles = append(subRoles, subRole)
+	}
+	sort.Strings(subRoles)
+
+	for _, subRole := range subRoles {
+		if subRole == "" {
+			return fmt.Errorf("application network policy contains an empty SubRole")
+		}
+
+		seen := map[ApplicationNetworkSubnet]bool{}
+		for _, networkSubnet := range p.ForSubRole(subRole) {
+			if networkSubnet.Network == "" {
+				return fmt.Errorf("application network policy for SubRole (%s) contains an entry without a network", subRole)
+			}
+
+			if seen[networkSubnet] {
+				return fmt.Errorf("application network policy for SubRole (%s) contains subnet (%s) in network (%s) more than once", subRole, networkSubnet.Subnet, networkSubnet.Network)
+			}
+			seen[networkSubnet] = true
+		}
+	}
+
+	return nil
+}
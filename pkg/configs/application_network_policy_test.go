@@ -0,0 +1,78 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package configs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v2"
+)
+
+type ApplicationNetworkPolicyTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ApplicationNetworkPolicyTestSuite) TestForSubRole() {
+	var policy ApplicationNetworkPolicy
+	err := yaml.Unmarshal([]byte(`
+Gateway:
+  - network: CAN
+  - network: CMN
+    subnet: network_hardware
+`), &policy)
+	suite.NoError(err)
+	suite.NoError(policy.Validate())
+
+	suite.Equal([]ApplicationNetworkSubnet{
+		{Network: "CAN", Subnet: "bootstrap_dhcp"},
+		{Network: "CMN", Subnet: "network_hardware"},
+	}, policy.ForSubRole("Gateway"))
+	suite.Empty(policy.ForSubRole("UAN"))
+}
+
+func (suite *ApplicationNetworkPolicyTestSuite) TestDefault() {
+	suite.NoError(DefaultApplicationNetworkPolicy.Validate())
+	suite.Equal([]ApplicationNetworkSubnet{
+		{Network: "CAN", Subnet: "bootstrap_dhcp"},
+		{Network: "CHN", Subnet: "bootstrap_dhcp"},
+	}, DefaultApplicationNetworkPolicy.ForSubRole("UAN"))
+}
+
+func (suite *ApplicationNetworkPolicyTestSuite) TestValidate_MissingNetwork() {
+	policy := ApplicationNetworkPolicy{
+		"UAN": {{Subnet: "bootstrap_dhcp"}},
+	}
+	suite.EqualError(policy.Validate(), "application network policy for SubRole (UAN) contains an entry without a network")
+}
+
+func (suite *ApplicationNetworkPolicyTestSuite) TestValidate_Duplicate() {
+	policy := ApplicationNetworkPolicy{
+		"UAN": {{Network: "CAN"}, {Network: "CAN", Subnet: "bootstrap_dhcp"}},
+	}
+	suite.EqualError(policy.Validate(), "application network policy for SubRole (UAN) contains subnet (bootstrap_dhcp) in network (CAN) more than once")
+}
+
+func TestApplicationNetworkPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(ApplicationNetworkPolicyTestSuite))
+}
@@ -22,7 +22,13 @@
 
 package configs
 
-import "sort"
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
 
 // The Key is xname as that is the only thing that can link the CCJ and SLS.
 type ApplicationNodeMetadataMap map[string]ApplicationNodeMetadata
@@ -31,6 +37,21 @@ type ApplicationNodeMetadata struct {
 	CANUCommonName string   `yaml:"canu_common_name,omitempty"`
 	SubRole        string   `yaml:"subrole"`
 	Aliases        []string `yaml:"aliases"`
+
+	// BMCOrdinal optionally overrides the BMC ordinal of an application node located within a dense quad node chassis.
+	// As the xname of the node depends on this value, the entry is matched to the CCJ using its CANU common name.
+	BMCOrdinal *int `yaml:"bmc_ordinal,omitempty"`
+}
+
+// FindByCANUCommonName will find the application node metadata with the given CANU common name.
+func (m ApplicationNodeMetadataMap) FindByCANUCommonName(commonName string) (string, ApplicationNodeMetadata, bool) {
+	for xname, metadata := range m {
+		if metadata.CANUCommonName != "" && metadata.CANUCommonName == commonName {
+			return xname, metadata, true
+		}
+	}
+
+	return "", ApplicationNodeMetadata{}, false
 }
 
 func (m ApplicationNodeMetadataMap) AllAliases() map[string][]string {
@@ -48,3 +69,69 @@ func (m ApplicationNodeMetadataMap) AllAliases() map[string][]string {
 	return allAliases
 
 }
+
+// MergeApplicationNodeMetadataYAML will add the given application node metadata to an existing application node
+// metadata YAML document. Existing entries and comments within the document are left as is, and only entries for
+// xnames not already present in the document are appended in sorted order.
+func MergeApplicationNodeMetadataYAML(existingRaw []byte, additions ApplicationNodeMetadataMap) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(existingRaw, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse application node metadata: %w", err)
+	}
+
+	// An empty file has no document, so start a new one
+	if document.Kind == 0 {
+		document = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 {
+		return nil, fmt.Errorf("unexpected application node metadata document structure")
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("application node metadata is not a map of xnames")
+	}
+
+	// Mapping nodes store their keys and values as alternating entries
+	existingXnames := map[string]bool{}
+	for i := 0; i < len(root.Content); i += 2 {
+		existingXnames[root.Content[i].Value] = true
+	}
+
+	// Sort the xnames being added so the output is deterministic
+	var xnames []string
+	for xname := range additions {
+		if !existingXnames[xname] {
+			xnames = append(xnames, xname)
+		}
+	}
+	sort.Strings(xnames)
+
+	for _, xname := range xnames {
+		var value yaml.Node
+		if err := value.Encode(additions[xname]); err != nil {
+			return nil, fmt.Errorf("failed to encode application node metadata for (%s): %w", xname, err)
+		}
+
+		root.Content = append(root.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: xname},
+			&value,
+		)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, fmt.Errorf("failed to encode application node metadata: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode application node metadata: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -83,6 +83,79 @@ func (suite *ApplicationNodeMetadataTestSuite) TestAllAliases_DuplicateAliases()
 	suite.Equal(expectedAliases, aliases)
 }
 
+func (suite *ApplicationNodeMetadataTestSuite) TestMergeApplicationNodeMetadataYAML() {
+	existing := `# Site managed application node metadata
+x3000c0s15b0n0:
+  subrole: UAN
+  aliases:
+    - uan01 # Primary UAN
+`
+
+	additions := ApplicationNodeMetadataMap{
+		"x3000c0s15b0n0": ApplicationNodeMetadata{
+			SubRole: "~~FIXME~~",
+			Aliases: []string{"~~FIXME~~"},
+		},
+		"x3001c0s16b0n0": ApplicationNodeMetadata{
+			CANUCommonName: "uan003",
+			SubRole:        "~~FIXME~~",
+			Aliases:        []string{"~~FIXME~~"},
+		},
+		"x3000c0s16b0n0": ApplicationNodeMetadata{
+			CANUCommonName: "lnet001",
+			SubRole:        "~~FIXME~~",
+			Aliases:        []string{"~~FIXME~~"},
+		},
+	}
+
+	expected := `# Site managed application node metadata
+x3000c0s15b0n0:
+  subrole: UAN
+  aliases:
+    - uan01 # Primary UAN
+x3000c0s16b0n0:
+  canu_common_name: lnet001
+  subrole: ~~FIXME~~
+  aliases:
+    - ~~FIXME~~
+x3001c0s16b0n0:
+  canu_common_name: uan003
+  subrole: ~~FIXME~~
+  aliases:
+    - ~~FIXME~~
+`
+
+	merged, err := MergeApplicationNodeMetadataYAML([]byte(existing), additions)
+	suite.NoError(err)
+	suite.Equal(expected, string(merged))
+}
+
+func (suite *ApplicationNodeMetadataTestSuite) TestMergeApplicationNodeMetadataYAML_EmptyFile() {
+	additions := ApplicationNodeMetadataMap{
+		"x3000c0s16b0n0": ApplicationNodeMetadata{
+			CANUCommonName: "lnet001",
+			SubRole:        "~~FIXME~~",
+			Aliases:        []string{"~~FIXME~~"},
+		},
+	}
+
+	expected := `x3000c0s16b0n0:
+  canu_common_name: lnet001
+  subrole: ~~FIXME~~
+  aliases:
+    - ~~FIXME~~
+`
+
+	merged, err := MergeApplicationNodeMetadataYAML([]byte{}, additions)
+	suite.NoError(err)
+	suite.Equal(expected, string(merged))
+}
+
+func (suite *ApplicationNodeMetadataTestSuite) TestMergeApplicationNodeMetadataYAML_NotAMap() {
+	_, err := MergeApplicationNodeMetadataYAML([]byte("- x3000c0s15b0n0\n"), ApplicationNodeMetadataMap{})
+	suite.EqualError(err, "application node metadata is not a map of xnames")
+}
+
 func TestApplicationNodeMetadataTestSuite(t *testing.T) {
 	suite.Run(t, new(ApplicationNodeMetadataTestSuite))
 }
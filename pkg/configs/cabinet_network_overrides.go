@@ -0,0 +1,122 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package configs
+
+import (
+	"fmt"
+	"sort"
+
+	"inet.af/netaddr"
+)
+
+// CabinetNetworkOverride contains the user specified VLAN, and optionally the CIDR, of a cabinet subnet.
+type CabinetNetworkOverride struct {
+	VlanID int16  `yaml:"vlan"`
+	CIDR   string `yaml:"cidr,omitempty"`
+}
+
+// Prefix will parse the CIDR of the override. If no CIDR was specified, then nil is returned.
+func (o CabinetNetworkOverride) Prefix() (*netaddr.IPPrefix, error) {
+	if o.CIDR == "" {
+		return nil, nil
+	}
+
+	prefix, err := netaddr.ParseIPPrefix(o.CIDR)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CIDR (%s): %w", o.CIDR, err)
+	}
+	if prefix != prefix.Masked() {
+		return nil, fmt.Errorf("CIDR (%s) has host bits set, expected (%s)", o.CIDR, prefix.Masked())
+	}
+
+	return &prefix, nil
+}
+
+// CabinetNetworkOverrides contains user specified cabinet subnet information to use instead of automatically allocating
+// it. The first key is the cabinet xname, and the second key is the SLS network name, such as HMN_RVR or NMN_RVR.
+type CabinetNetworkOverrides map[string]map[string]CabinetNetworkOverride
+
+// Find will retrieve the override for the given cabinet and network, if present.
+func (cno CabinetNetworkOverrides) Find(cabinetXname, networkName string) (CabinetNetworkOverride, bool) {
+	override, ok := cno[cabinetXname][networkName]
+	return override, ok
+}
+
+// Validate will verify each override has a valid VLAN and CIDR, and that the overrides do not reuse a VLAN or have
+// overlapping CIDRs within the same network.
+func (cno CabinetNetworkOverrides) Validate() error {
+	// Sort the cabinets so errors are deterministic
+	var cabinets []string
+	for cabinet := range cno {
+		cabinets = append(cabinets, cabinet)
+	}
+	sort.Strings(cabinets)
+
+	type usage struct {
+		cabinet string
+		prefix  *netaddr.IPPrefix
+	}
+
+	vlansInUse := map[string]map[int16]string{}
+	prefixesInUse := map[string][]usage{}
+	for _, cabinet := range cabinets {
+		var networkNames []string
+		for networkName := range cno[cabinet] {
+			networkNames = append(networkNames, networkName)
+		}
+		sort.Strings(networkNames)
+
+		for _, networkName := range networkNames {
+			override := cno[cabinet][networkName]
+
+			if override.VlanID < 1 || 4094 < override.VlanID {
+				return fmt.Errorf("invalid VLAN (%d) for cabinet (%s) in network (%s), expected 1 through 4094", override.VlanID, cabinet, networkName)
+			}
+
+			if vlansInUse[networkName] == nil {
+				vlansInUse[networkName] = map[int16]string{}
+			}
+			if otherCabinet, present := vlansInUse[networkName][override.VlanID]; present {
+				return fmt.Errorf("VLAN (%d) in network (%s) is used by both cabinet (%s) and (%s)", override.VlanID, networkName, otherCabinet, cabinet)
+			}
+			vlansInUse[networkName][override.VlanID] = cabinet
+
+			prefix, err := override.Prefix()
+			if err != nil {
+				return fmt.Errorf("invalid override for cabinet (%s) in network (%s): %w", cabinet, networkName, err)
+			}
+			if prefix == nil {
+				continue
+			}
+
+			for _, other := range prefixesInUse[networkName] {
+				if other.prefix.Overlaps(*prefix) {
+					return fmt.Errorf("CIDR (%s) of cabinet (%s) overlaps with CIDR (%s) of cabinet (%s) in network (%s)", prefix, cabinet, other.prefix, other.cabinet, networkName)
+				}
+			}
+			prefixesInUse[networkName] = append(prefixesInUse[networkName], usage{cabinet: cabinet, prefix: prefix})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package configs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"inet.af/netaddr"
+)
+
+type CabinetNetworkOverridesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CabinetNetworkOverridesTestSuite) TestFind() {
+	overrides := CabinetNetworkOverrides{
+		"x3001": {
+			"HMN_RVR": {VlanID: 1600, CIDR: "10.107.8.0/22"},
+		},
+	}
+
+	override, ok := overrides.Find("x3001", "HMN_RVR")
+	suite.True(ok)
+	suite.Equal(CabinetNetworkOverride{VlanID: 1600, CIDR: "10.107.8.0/22"}, override)
+
+	_, ok = overrides.Find("x3001", "NMN_RVR")
+	suite.False(ok)
+
+	_, ok = overrides.Find("x3002", "HMN_RVR")
+	suite.False(ok)
+}
+
+func (suite *CabinetNetworkOverridesTestSuite) TestPrefix() {
+	prefix, err := CabinetNetworkOverride{VlanID: 1600, CIDR: "10.107.8.0/22"}.Prefix()
+	suite.NoError(err)
+	suite.Equal(netaddr.MustParseIPPrefix("10.107.8.0/22"), *prefix)
+
+	prefix, err = CabinetNetworkOverride{VlanID: 1600}.Prefix()
+	suite.NoError(err)
+	suite.Nil(prefix)
+}
+
+func (suite *CabinetNetworkOverridesTestSuite) TestPrefix_HostBitsSet() {
+	_, err := CabinetNetworkOverride{VlanID: 1600, CIDR: "10.107.8.1/22"}.Prefix()
+	suite.EqualError(err, "CIDR (10.107.8.1/22) has host bits set, expected (10.107.8.0/22)")
+}
+
+func (suite *CabinetNetworkOverridesTestSuite) TestValidate() {
+	overrides := CabinetNetworkOverrides{
+		"x3001": {
+			"HMN_RVR": {VlanID: 1600, CIDR: "10.107.8.0/22"},
+			"NMN_RVR": {VlanID: 1800},
+		},
+		"x3002": {
+			"HMN_RVR": {VlanID: 1601, CIDR: "10.107.12.0/22"},
+			"NMN_RVR": {VlanID: 1600},
+		},
+	}
+
+	suite.NoError(overrides.Validate())
+}
+
+func (suite *CabinetNetworkOverridesTestSuite) TestValidate_InvalidVLAN() {
+	overrides := CabinetNetworkOverrides{
+		"x3001": {
+			"HMN_RVR": {VlanID: 4095},
+		},
+	}
+
+	suite.EqualError(overrides.Validate(), "invalid VLAN (4095) for cabinet (x3001) in network (HMN_RVR), expected 1 through 4094")
+}
+
+func (suite *CabinetNetworkOverridesTestSuite) TestValidate_DuplicateVLAN() {
+	overrides := CabinetNetworkOverrides{
+		"x3001": {
+			"HMN_RVR": {VlanID: 1600},
+		},
+		"x3002": {
+			"HMN_RVR": {VlanID: 1600},
+		},
+	}
+
+	suite.EqualError(overrides.Validate(), "VLAN (1600) in network (HMN_RVR) is used by both cabinet (x3001) and (x3002)")
+}
+
+func (suite *CabinetNetworkOverridesTestSuite) TestValidate_OverlappingCIDR() {
+	overrides := CabinetNetworkOverrides{
+		"x3001": {
+			"HMN_RVR": {VlanID: 1600, CIDR: "10.107.8.0/22"},
+		},
+		"x3002": {
+			"HMN_RVR": {VlanID: 1601, CIDR: "10.107.10.0/24"},
+		},
+	}
+
+	suite.EqualError(overrides.Validate(), "CIDR (10.107.10.0/24) of cabinet (x3002) overlaps with CIDR (10.107.8.0/22) of cabinet (x3001) in network (HMN_RVR)")
+}
+
+func TestCabinetNetworkOverridesTestSuite(t *testing.T) {
+	suite.Run(t, new(CabinetNetworkOverridesTestSuite))
+}
@@ -0,0 +1,58 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package configs
+
+import "fmt"
+
+// WellKnownHostRecord describes a host record in the BSS Global boot parameters for an IP reservation in SLS, such as
+// a VIP.
+type WellKnownHostRecord struct {
+	Network     string   `yaml:"network"`
+	Subnet      string   `yaml:"subnet"`
+	Reservation string   `yaml:"reservation"`
+	Aliases     []string `yaml:"aliases"`
+}
+
+// WellKnownHostRecords contains the host records for IP reservations in SLS that are always expected to be present.
+type WellKnownHostRecords []WellKnownHostRecord
+
+// DefaultWellKnownHostRecords matches the VIP and API gateway host records created by CSI.
+var DefaultWellKnownHostRecords = WellKnownHostRecords{
+	{Network: "NMN", Subnet: "bootstrap_dhcp", Reservation: "kubeapi-vip", Aliases: []string{"kubeapi-vip", "kubeapi-vip.nmn"}},
+	{Network: "NMN", Subnet: "bootstrap_dhcp", Reservation: "rgw-vip", Aliases: []string{"rgw-vip", "rgw-vip.nmn"}},
+	{Network: "NMNLB", Subnet: "nmn_metallb_address_pool", Reservation: "istio-ingressgateway", Aliases: []string{"packages.local", "registry.local"}},
+}
+
+// Validate will verify every host record identifies an IP reservation and has at least one alias.
+func (records WellKnownHostRecords) Validate() error {
+	for i, record := range records {
+		if record.Network == "" || record.Subnet == "" || record.Reservation == "" {
+			return fmt.Errorf("host record %d must have a network, subnet, and reservation", i)
+		}
+		if len(record.Aliases) == 0 {
+			return fmt.Errorf("host record for reservation (%s) in subnet (%s) in network (%s) has no aliases", record.Reservation, record.Subnet, record.Network)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package configs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v2"
+)
+
+type WellKnownHostRecordsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *WellKnownHostRecordsTestSuite) TestUnmarshal() {
+	var records WellKnownHostRecords
+	err := yaml.Unmarshal([]byte(`
+- network: NMN
+  subnet: bootstrap_dhcp
+  reservation: kubeapi-vip
+  aliases: [kubeapi-vip, kubeapi-vip.nmn]
+`), &records)
+	suite.NoError(err)
+	suite.NoError(records.Validate())
+	suite.Equal(DefaultWellKnownHostRecords[:1], records)
+}
+
+func (suite *WellKnownHostRecordsTestSuite) TestValidate() {
+	suite.NoError(DefaultWellKnownHostRecords.Validate())
+
+	suite.EqualError(WellKnownHostRecords{{Network: "NMN", Subnet: "bootstrap_dhcp"}}.Validate(),
+		"host record 0 must have a network, subnet, and reservation")
+	suite.EqualError(WellKnownHostRecords{{Network: "NMN", Subnet: "bootstrap_dhcp", Reservation: "rgw-vip"}}.Validate(),
+		"host record for reservation (rgw-vip) in subnet (bootstrap_dhcp) in network (NMN) has no aliases")
+}
+
+func TestWellKnownHostRecordsTestSuite(t *testing.T) {
+	suite.Run(t, new(WellKnownHostRecordsTestSuite))
+}
@@ -0,0 +1,73 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package configs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NCNInterfaceNaming controls the names of the network interfaces of a management NCN. A zero value field is
+// determined from the existing BSS IPAM metadata of the NCN, or uses the default value.
+type NCNInterfaceNaming struct {
+	// ParentDevice is the device the VLAN interfaces of the NCN are created on, such as bond0.
+	ParentDevice string `yaml:"parent_device,omitempty"`
+
+	// VLANInterfaceFormat is the format of the name of the VLAN interface of a network. It is formatted with the
+	// parent device and the lower case network name, such as %s.%s0 for bond0.nmn0.
+	VLANInterfaceFormat string `yaml:"vlan_interface_format,omitempty"`
+}
+
+// DefaultNCNInterfaceNaming matches the interfaces created by CSI, such as bond0.nmn0.
+var DefaultNCNInterfaceNaming = NCNInterfaceNaming{
+	ParentDevice:        "bond0",
+	VLANInterfaceFormat: "%s.%s0",
+}
+
+// Validate will verify the VLAN interface format contains exactly two %s verbs.
+func (n NCNInterfaceNaming) Validate() error {
+	if n.VLANInterfaceFormat == "" {
+		return nil
+	}
+
+	if strings.Count(n.VLANInterfaceFormat, "%") != 2 || strings.Count(n.VLANInterfaceFormat, "%s") != 2 {
+		return fmt.Errorf("VLAN interface format (%s) must contain exactly two %%s verbs for the parent device and network name", n.VLANInterfaceFormat)
+	}
+
+	return nil
+}
+
+// NCNInterfaceNamingOverrides contains the interface naming of management NCNs that do not use the default or
+// existing interface naming. The key is the xname of the NCN.
+type NCNInterfaceNamingOverrides map[string]NCNInterfaceNaming
+
+// Validate will verify the interface naming of every NCN.
+func (o NCNInterfaceNamingOverrides) Validate() error {
+	for xname, naming := range o {
+		if err := naming.Validate(); err != nil {
+			return fmt.Errorf("invalid interface naming for NCN (%s): %w", xname, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package configs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NCNInterfaceNamingTestSuite struct {
+	suite.Suite
+}
+
+func (suite *NCNInterfaceNamingTestSuite) TestValidate() {
+	suite.NoError(DefaultNCNInterfaceNaming.Validate())
+	suite.NoError(NCNInterfaceNaming{ParentDevice: "bond1"}.Validate())
+	suite.NoError(NCNInterfaceNaming{VLANInterfaceFormat: "%s.%s"}.Validate())
+}
+
+func (suite *NCNInterfaceNamingTestSuite) TestValidate_InvalidFormat() {
+	overrides := NCNInterfaceNamingOverrides{
+		"x3000c0s1b0n0": {VLANInterfaceFormat: "%s.vlan%d"},
+	}
+
+	suite.EqualError(overrides.Validate(), "invalid interface naming for NCN (x3000c0s1b0n0): VLAN interface format (%s.vlan%d) must contain exactly two %s verbs for the parent device and network name")
+}
+
+func TestNCNInterfaceNamingTestSuite(t *testing.T) {
+	suite.Run(t, new(NCNInterfaceNamingTestSuite))
+}
@@ -0,0 +1,97 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package configs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NIDAssignmentMode controls how the NID of a compute node in the CCJ is determined.
+type NIDAssignmentMode string
+
+const (
+	// NIDAssignmentModeCommonName parses the NID out of the CANU common name of the node, for example cn003 has a
+	// NID of 3. This is the default mode.
+	NIDAssignmentModeCommonName NIDAssignmentMode = "common_name"
+
+	// NIDAssignmentModeCabinetOffset adds the last number in the CANU common name of the node to the base NID of the
+	// cabinet it is located in, for example cn-a-12 in a cabinet with a base NID of 1000 has a NID of 1012.
+	NIDAssignmentModeCabinetOffset NIDAssignmentMode = "cabinet_offset"
+
+	// NIDAssignmentModeExplicit looks up the NID of the node by its CANU common name in an explicit map.
+	NIDAssignmentModeExplicit NIDAssignmentMode = "explicit"
+)
+
+// DefaultComputeAliasFormat is the format of the alias given to compute nodes, which is formatted with the NID.
+const DefaultComputeAliasFormat = "nid%06d"
+
+// NIDAssignment controls how compute nodes from the CCJ are given their NID and alias. The zero value parses the NID
+// from the CANU common name and uses the nid%06d alias format.
+type NIDAssignment struct {
+	Mode        NIDAssignmentMode `yaml:"mode"`
+	AliasFormat string            `yaml:"alias_format,omitempty"`
+
+	// The key is the cabinet xname, and the value is the base NID the last number in the CANU common name of a compute
+	// node in the cabinet is added to. For example with a base NID of 1000, cn-a-01 has a NID of 1001.
+	CabinetBaseNIDs map[string]int `yaml:"cabinet_base_nids,omitempty"`
+
+	// The key is the CANU common name of the compute node.
+	NIDs map[string]int `yaml:"nids,omitempty"`
+}
+
+// Validate will verify the NID assignment has a known mode, and contains the information required by that mode.
+func (n NIDAssignment) Validate() error {
+	switch n.Mode {
+	case "", NIDAssignmentModeCommonName:
+		// Nothing else is required
+	case NIDAssignmentModeCabinetOffset:
+		if len(n.CabinetBaseNIDs) == 0 {
+			return fmt.Errorf("nid assignment mode (%s) requires cabinet base NIDs to be provided", n.Mode)
+		}
+	case NIDAssignmentModeExplicit:
+		if len(n.NIDs) == 0 {
+			return fmt.Errorf("nid assignment mode (%s) requires NIDs to be provided", n.Mode)
+		}
+	default:
+		return fmt.Errorf("unknown nid assignment mode (%s)", n.Mode)
+	}
+
+	if n.AliasFormat != "" {
+		if alias := fmt.Sprintf(n.AliasFormat, 1); strings.Contains(alias, "%!") {
+			return fmt.Errorf("invalid compute alias format (%s) expected a single integer verb such as %s", n.AliasFormat, DefaultComputeAliasFormat)
+		}
+	}
+
+	return nil
+}
+
+// Alias will build the alias of a compute node with the given NID.
+func (n NIDAssignment) Alias(nid int) string {
+	aliasFormat := n.AliasFormat
+	if aliasFormat == "" {
+		aliasFormat = DefaultComputeAliasFormat
+	}
+
+	return fmt.Sprintf(aliasFormat, nid)
+}
@@ -0,0 +1,100 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package diff
+
+import (
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ANSI escape codes used to colorize diffs on a terminal
+const (
+	colorReset = "\033[0m"
+	colorBold  = "\033[1m"
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorCyan  = "\033[36m"
+)
+
+// noNewlineMarker follows the last line of text without a trailing newline, as done by diff and git.
+const noNewlineMarker = "\\ No newline at end of file"
+
+// Unified will generate a unified diff with 3 lines of context between the existing and modified text of the named
+// item. An empty string is returned when the text is the same.
+func Unified(name, existing, modified string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(existing),
+		B:        splitLines(modified),
+		FromFile: "a/" + name,
+		ToFile:   "b/" + name,
+		Context:  3,
+	})
+}
+
+// splitLines will split text into lines that all end with a newline. When the text does not end with a newline, then
+// the last line is followed by a "\ No newline at end of file" marker, so a change to only the trailing newline still
+// shows up in the diff. Empty text has no lines.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += "\n" + noNewlineMarker + "\n"
+	return lines
+}
+
+// Colorize will color the headers, hunk ranges, and removed and added lines of a unified diff for display on a
+// terminal.
+func Colorize(unifiedDiff string) string {
+	lines := strings.SplitAfter(unifiedDiff, "\n")
+	for i, line := range lines {
+		content := strings.TrimSuffix(line, "\n")
+		if content == "" {
+			continue
+		}
+
+		var color string
+		switch {
+		case strings.HasPrefix(content, "---"), strings.HasPrefix(content, "+++"):
+			color = colorBold
+		case strings.HasPrefix(content, "@@"):
+			color = colorCyan
+		case strings.HasPrefix(content, "-"):
+			color = colorRed
+		case strings.HasPrefix(content, "+"):
+			color = colorGreen
+		default:
+			continue
+		}
+
+		lines[i] = color + content + colorReset + line[len(content):]
+	}
+
+	return strings.Join(lines, "")
+}
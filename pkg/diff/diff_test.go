@@ -0,0 +1,85 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DiffTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DiffTestSuite) TestUnified() {
+	unifiedDiff, err := Unified("Global/host_records", "10.252.1.2 kubeapi-vip\n10.254.0.4 sw-leaf-bmc-001\n", "10.252.1.2 kubeapi-vip\n10.254.0.5 sw-leaf-bmc-002\n")
+	suite.NoError(err)
+	suite.Equal("--- a/Global/host_records\n"+
+		"+++ b/Global/host_records\n"+
+		"@@ -1,2 +1,2 @@\n"+
+		" 10.252.1.2 kubeapi-vip\n"+
+		"-10.254.0.4 sw-leaf-bmc-001\n"+
+		"+10.254.0.5 sw-leaf-bmc-002\n", unifiedDiff)
+}
+
+func (suite *DiffTestSuite) TestUnified_NoChanges() {
+	unifiedDiff, err := Unified("ifroute-bond0.nmn0", "10.106.0.0/22 10.252.0.1 - bond0.nmn0\n", "10.106.0.0/22 10.252.0.1 - bond0.nmn0\n")
+	suite.NoError(err)
+	suite.Empty(unifiedDiff)
+}
+
+func (suite *DiffTestSuite) TestUnified_TrailingNewline() {
+	unifiedDiff, err := Unified("ifroute-bond0.nmn0", "10.106.0.0/22 10.252.0.1 - bond0.nmn0", "10.106.0.0/22 10.252.0.1 - bond0.nmn0\n")
+	suite.NoError(err)
+	suite.Equal("--- a/ifroute-bond0.nmn0\n"+
+		"+++ b/ifroute-bond0.nmn0\n"+
+		"@@ -1 +1 @@\n"+
+		"-10.106.0.0/22 10.252.0.1 - bond0.nmn0\n"+
+		"\\ No newline at end of file\n"+
+		"+10.106.0.0/22 10.252.0.1 - bond0.nmn0\n", unifiedDiff)
+}
+
+func (suite *DiffTestSuite) TestUnified_Added() {
+	unifiedDiff, err := Unified("ifroute-bond0.hmn0", "", "10.107.0.0/22 10.254.0.1 - bond0.hmn0")
+	suite.NoError(err)
+	suite.Equal("--- a/ifroute-bond0.hmn0\n"+
+		"+++ b/ifroute-bond0.hmn0\n"+
+		"@@ -0,0 +1 @@\n"+
+		"+10.107.0.0/22 10.254.0.1 - bond0.hmn0\n"+
+		"\\ No newline at end of file\n", unifiedDiff)
+}
+
+func (suite *DiffTestSuite) TestColorize() {
+	colorized := Colorize("--- a/x\n+++ b/x\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n")
+	suite.Equal("\033[1m--- a/x\033[0m\n"+
+		"\033[1m+++ b/x\033[0m\n"+
+		"\033[36m@@ -1,2 +1,2 @@\033[0m\n"+
+		" a\n"+
+		"\033[31m-b\033[0m\n"+
+		"\033[32m+c\033[0m\n", colorized)
+}
+
+func TestDiffTestSuite(t *testing.T) {
+	suite.Run(t, new(DiffTestSuite))
+}
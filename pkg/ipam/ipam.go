@@ -24,14 +24,39 @@ package ipam
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
 
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
 	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
 	"github.com/Cray-HPE/hms-xname/xnames"
 	"inet.af/netaddr"
 )
 
+var (
+	// ErrNetworkExhausted is returned when a network has no free space left for a new subnet.
+	ErrNetworkExhausted = errors.New("network space has been exhausted")
+
+	// ErrSubnetExhausted is returned when a subnet has no free IPs left, or its static IP address range cannot be
+	// expanded any further.
+	ErrSubnetExhausted = errors.New("subnet has no available IPs")
+)
+
+// OverrideError is returned when the user provided VLAN or CIDR override of a cabinet subnet cannot be used.
+type OverrideError struct {
+	Err error
+}
+
+func (e *OverrideError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *OverrideError) Unwrap() error {
+	return e.Err
+}
+
 func ExistingIPAddresses(slsSubnet sls_common.IPV4Subnet) (*netaddr.IPSet, error) {
 	var existingIPAddresses netaddr.IPSetBuilder
 	gatewayIP, ok := netaddr.FromStdIP(slsSubnet.Gateway)
@@ -62,71 +87,148 @@ func FindNextAvailableIP(slsSubnet sls_common.IPV4Subnet) (netaddr.IP, error) {
 		return netaddr.IP{}, err
 	}
 
-	startingIP := subnet.Range().From().Next() // Start at the first usable available IP in the subnet.
-	endingIP := subnet.Range().To()            // This is the broadcast IP
+	// The usable IPs of the subnet exclude the network and broadcast IPs
+	usableIPs := netaddr.IPRangeFrom(subnet.Range().From().Next(), subnet.Range().To().Prior())
+	if !usableIPs.IsValid() {
+		return netaddr.IP{}, ErrSubnetExhausted
+	}
 
-	for ip := startingIP; ip.Less(endingIP); ip = ip.Next() {
-		if !existingIPAddressesSet.Contains(ip) {
-			return ip, nil
-		}
+	availableIPs, err := subtractIPSet(usableIPs, existingIPAddressesSet)
+	if err != nil {
+		return netaddr.IP{}, err
 	}
 
-	return netaddr.IP{}, fmt.Errorf("subnet has no available IPs")
+	// The ranges of an IPSet are sorted, so the first range holds the lowest available IP.
+	availableRanges := availableIPs.Ranges()
+	if len(availableRanges) == 0 {
+		return netaddr.IP{}, ErrSubnetExhausted
+	}
+
+	return availableRanges[0].From(), nil
 }
 
 func AdvanceIP(ip netaddr.IP, n uint32) (netaddr.IP, error) {
-	if ip.Is6() {
-		return netaddr.IP{}, fmt.Errorf("IPv6 is not supported")
-	}
 	if ip.IsZero() {
 		return netaddr.IP{}, fmt.Errorf("empty IP address provided")
 	}
 
-	// This is kind of crude hack, but if it works it works.
+	if ip.Is6() {
+		// Add n to the address with carry, starting from the least significant byte. Any carry left over after the most
+		// significant byte means the address space has overflowed.
+		ipBytes := ip.As16()
+		carry := uint64(n)
+		for i := len(ipBytes) - 1; i >= 0 && carry != 0; i-- {
+			sum := uint64(ipBytes[i]) + carry
+			ipBytes[i] = byte(sum)
+			carry = sum >> 8
+		}
+		if carry != 0 {
+			return netaddr.IP{}, fmt.Errorf("advancing IP (%s) by %d overflows the IPv6 address space", ip, n)
+		}
+
+		return netaddr.IPv6Raw(ipBytes), nil
+	}
+
+	// Perform the math with 64 bits, so advancing past 255.255.255.255 can be detected instead of wrapping around.
+	ipRaw := uint64(ipv4ToUint32(ip)) + uint64(n)
+	if ipRaw > math.MaxUint32 {
+		return netaddr.IP{}, fmt.Errorf("advancing IP (%s) by %d overflows the IPv4 address space", ip, n)
+	}
+
+	return uint32ToIPv4(uint32(ipRaw)), nil
+}
+
+func ipv4ToUint32(ip netaddr.IP) uint32 {
 	ipOctets := ip.As4()
-	ipRaw := binary.BigEndian.Uint32(ipOctets[:])
+	return binary.BigEndian.Uint32(ipOctets[:])
+}
 
-	// Advance the IP by n
-	ipRaw += n
+func uint32ToIPv4(ipRaw uint32) netaddr.IP {
+	var ipOctets [4]byte
+	binary.BigEndian.PutUint32(ipOctets[:], ipRaw)
+	return netaddr.IPFrom4(ipOctets)
+}
 
-	// Now put it back into an netaddr.IP
-	var updatedIPOctets [4]byte
-	binary.BigEndian.PutUint32(updatedIPOctets[:], ipRaw)
+// ipRangeSize returns the number of addresses within the range. The size of IPv6 ranges is capped at the maximum
+// value of an uint64.
+func ipRangeSize(ipRange netaddr.IPRange) uint64 {
+	if ipRange.From().Is4() {
+		return uint64(ipv4ToUint32(ipRange.To())) - uint64(ipv4ToUint32(ipRange.From())) + 1
+	}
+
+	fromBytes := ipRange.From().As16()
+	toBytes := ipRange.To().As16()
+	size := new(big.Int).Sub(new(big.Int).SetBytes(toBytes[:]), new(big.Int).SetBytes(fromBytes[:]))
+	size.Add(size, big.NewInt(1))
+	if !size.IsUint64() {
+		return math.MaxUint64
+	}
 
-	return netaddr.IPFrom4(updatedIPOctets), nil
+	return size.Uint64()
 }
 
-func SplitNetwork(network netaddr.IPPrefix, subnetMaskOneBits uint8) ([]netaddr.IPPrefix, error) {
-	if subnetMaskOneBits < 16 || 30 < subnetMaskOneBits {
-		return nil, fmt.Errorf("invalid subnet mask provided /%d", subnetMaskOneBits)
+// subtractIPSet will remove the IPs in the given set from the range.
+func subtractIPSet(ipRange netaddr.IPRange, ipSet *netaddr.IPSet) (*netaddr.IPSet, error) {
+	var builder netaddr.IPSetBuilder
+	builder.AddRange(ipRange)
+	builder.RemoveSet(ipSet)
+
+	return builder.IPSet()
+}
+
+// validateSubnetMask verifies subnets of the given size can be carved out of the network. IPv4 subnets must be between
+// a /16 and /30, and IPv6 subnets must be between a /48 and /126.
+func validateSubnetMask(network netaddr.IPPrefix, subnetMaskOneBits uint8) error {
+	minBits, maxBits := uint8(16), uint8(30)
+	if network.IP().Is6() {
+		minBits, maxBits = 48, 126
 	}
 
-	subnetStartIP := network.Range().From()
+	if subnetMaskOneBits < minBits || maxBits < subnetMaskOneBits || subnetMaskOneBits < network.Bits() {
+		return fmt.Errorf("invalid subnet mask provided /%d", subnetMaskOneBits)
+	}
 
-	// TODO add a counter to prevent this loop from going in forever!
-	var subnets []netaddr.IPPrefix
-	for {
-		subnets = append(subnets, netaddr.IPPrefixFrom(subnetStartIP, subnetMaskOneBits))
+	return nil
+}
 
-		advanceBy := uint32(math.Pow(2, float64(32-subnetMaskOneBits)))
+func SplitNetwork(network netaddr.IPPrefix, subnetMaskOneBits uint8) ([]netaddr.IPPrefix, error) {
+	network = network.Masked()
+	if err := validateSubnetMask(network, subnetMaskOneBits); err != nil {
+		return nil, err
+	}
 
-		// Now advance!
-		var err error
-		subnetStartIP, err = AdvanceIP(subnetStartIP, advanceBy)
-		if err != nil {
-			return nil, err
-		}
+	// Splitting a network into more than 65536 subnets is most likely a mistake, and would use a lot of memory.
+	if subnetMaskOneBits-network.Bits() > 16 {
+		return nil, fmt.Errorf("splitting network (%s) into /%d subnets would create too many subnets", network, subnetMaskOneBits)
+	}
 
-		if network.Range().To().Less(subnetStartIP) {
-			break
-		}
+	// The number of subnets is known up front, so there is no need to walk past the end of the network.
+	subnetCount := 1 << (subnetMaskOneBits - network.Bits())
+
+	subnets := make([]netaddr.IPPrefix, 0, subnetCount)
+	subnetStartIP := network.IP()
+	for i := 0; i < subnetCount; i++ {
+		subnet := netaddr.IPPrefixFrom(subnetStartIP, subnetMaskOneBits)
+		subnets = append(subnets, subnet)
+
+		// The IP after the last IP of the subnet is the start of the next subnet
+		subnetStartIP = subnet.Range().To().Next()
 	}
 
 	return subnets, nil
 }
 
-func FindNextAvailableSubnet(slsNetwork sls_common.NetworkExtraProperties) (netaddr.IPPrefix, error) {
-	// TODO make the /22 configurable
+func FindNextAvailableSubnet(slsNetwork sls_common.NetworkExtraProperties, subnetMaskOneBits uint8) (netaddr.IPPrefix, error) {
+	network, err := netaddr.ParseIPPrefix(slsNetwork.CIDR)
+	if err != nil {
+		return netaddr.IPPrefix{}, err
+	}
+	network = network.Masked()
+
+	if err := validateSubnetMask(network, subnetMaskOneBits); err != nil {
+		return netaddr.IPPrefix{}, err
+	}
+
 	var existingSubnets netaddr.IPSetBuilder
 	for _, slsSubnet := range slsNetwork.Subnets {
 		subnet, err := netaddr.ParseIPPrefix(slsSubnet.CIDR)
@@ -134,7 +236,7 @@ func FindNextAvailableSubnet(slsNetwork sls_common.NetworkExtraProperties) (neta
 			return netaddr.IPPrefix{}, fmt.Errorf("failed to parse subnet CIDR (%v): %w", slsSubnet.CIDR, err)
 		}
 
-		existingSubnets.AddPrefix(subnet)
+		existingSubnets.AddPrefix(subnet.Masked())
 	}
 
 	existingSubnetsSet, err := existingSubnets.IPSet()
@@ -142,30 +244,76 @@ func FindNextAvailableSubnet(slsNetwork sls_common.NetworkExtraProperties) (neta
 		return netaddr.IPPrefix{}, err
 	}
 
-	network, err := netaddr.ParseIPPrefix(slsNetwork.CIDR)
+	// Find the lowest free block of the requested size within the unused space of the network. The existing subnets
+	// may be of a different size than the subnet being allocated, which the IPSet takes care of.
+	availableSpace, err := subtractIPSet(network.Range(), existingSubnetsSet)
 	if err != nil {
 		return netaddr.IPPrefix{}, err
 	}
 
-	availableSubnets, err := SplitNetwork(network, 22)
+	subnet, _, ok := availableSpace.RemoveFreePrefix(subnetMaskOneBits)
+	if !ok {
+		return netaddr.IPPrefix{}, ErrNetworkExhausted
+	}
+
+	return subnet, nil
+}
+
+// verifySubnetAvailable will verify the subnet is within the network, and does not overlap with any existing subnets.
+func verifySubnetAvailable(slsNetwork sls_common.NetworkExtraProperties, subnet netaddr.IPPrefix) error {
+	network, err := netaddr.ParseIPPrefix(slsNetwork.CIDR)
 	if err != nil {
-		return netaddr.IPPrefix{}, err
+		return fmt.Errorf("failed to parse network CIDR (%v): %w", slsNetwork.CIDR, err)
 	}
-	for _, subnet := range availableSubnets {
-		if existingSubnetsSet.Contains(subnet.IP()) {
-			continue
+
+	if !network.Contains(subnet.IP()) || network.Bits() > subnet.Bits() {
+		return fmt.Errorf("subnet is not contained within the network CIDR (%s)", slsNetwork.CIDR)
+	}
+
+	for _, slsSubnet := range slsNetwork.Subnets {
+		existingSubnet, err := netaddr.ParseIPPrefix(slsSubnet.CIDR)
+		if err != nil {
+			return fmt.Errorf("failed to parse subnet CIDR (%v): %w", slsSubnet.CIDR, err)
 		}
 
-		return subnet, nil
+		if existingSubnet.Overlaps(subnet) {
+			return fmt.Errorf("subnet overlaps with existing subnet (%s) with CIDR (%s)", slsSubnet.Name, slsSubnet.CIDR)
+		}
 	}
 
-	return netaddr.IPPrefix{}, fmt.Errorf("network space has been exhausted")
+	return nil
 }
 
-func AllocateCabinetSubnet(networkName string, slsNetwork sls_common.NetworkExtraProperties, xname xnames.Cabinet, vlanOverride *int16) (sls_common.IPV4Subnet, error) {
-	cabinetSubnet, err := FindNextAvailableSubnet(slsNetwork)
-	if err != nil {
-		return sls_common.IPV4Subnet{}, fmt.Errorf("failed to allocate subnet for (%s) in CIDR (%s)", xname.String(), slsNetwork.CIDR)
+// AllocateCabinetSubnet will allocate a new cabinet subnet within the given network. The VLAN and CIDR of the subnet are
+// automatically allocated unless an override is provided. VLANs in use by other networks in the VLAN registry are not
+// allocated.
+func AllocateCabinetSubnet(networkName string, slsNetwork sls_common.NetworkExtraProperties, xname xnames.Cabinet, vlanOverride *int16, cidrOverride *netaddr.IPPrefix, addressingPlan configs.SubnetAddressingPlan, vlanRegistry VLANRegistry) (sls_common.IPV4Subnet, error) {
+	addressingPlan = addressingPlan.WithDefaults()
+	if cidrOverride != nil {
+		// The size of the subnet comes from the override, but the rest of the addressing plan still applies
+		addressingPlan.PrefixLength = cidrOverride.Bits()
+	}
+	if err := addressingPlan.Validate(slsNetwork.CIDR); err != nil {
+		err = fmt.Errorf("invalid addressing plan for network (%s): %w", networkName, err)
+		if cidrOverride != nil {
+			return sls_common.IPV4Subnet{}, &OverrideError{Err: err}
+		}
+		return sls_common.IPV4Subnet{}, err
+	}
+
+	var cabinetSubnet netaddr.IPPrefix
+	if cidrOverride != nil {
+		if err := verifySubnetAvailable(slsNetwork, *cidrOverride); err != nil {
+			return sls_common.IPV4Subnet{}, &OverrideError{Err: fmt.Errorf("unable to use subnet (%s) for (%s): %w", cidrOverride, xname.String(), err)}
+		}
+
+		cabinetSubnet = *cidrOverride
+	} else {
+		var err error
+		cabinetSubnet, err = FindNextAvailableSubnet(slsNetwork, addressingPlan.PrefixLength)
+		if err != nil {
+			return sls_common.IPV4Subnet{}, fmt.Errorf("failed to allocate subnet for (%s) in CIDR (%s): %w", xname.String(), slsNetwork.CIDR, err)
+		}
 	}
 
 	// Verify this subnet is new
@@ -176,20 +324,27 @@ func AllocateCabinetSubnet(networkName string, slsNetwork sls_common.NetworkExtr
 		}
 	}
 
+	// Determine the current vlans in use by other cabinets
+	vlansInUse := map[int16]bool{}
+	for _, existingSubnet := range slsNetwork.Subnets {
+		vlansInUse[existingSubnet.VlanID] = true
+	}
+
 	// Calculate VLAN if one was not provided
 	vlan := int16(-1)
 	if vlanOverride != nil {
+		if vlansInUse[*vlanOverride] {
+			return sls_common.IPV4Subnet{}, &OverrideError{Err: fmt.Errorf("VLAN (%d) is already in use within network (%s)", *vlanOverride, networkName)}
+		}
+		if vlanRegistry.InUseByOtherNetwork(*vlanOverride, networkName) {
+			return sls_common.IPV4Subnet{}, &OverrideError{Err: fmt.Errorf("VLAN (%d) is already in use by other networks %v", *vlanOverride, vlanRegistry.Networks(*vlanOverride))}
+		}
+
 		vlan = *vlanOverride
 	} else {
 		// Look at other cabinets in the subnet and pick one.
 		// TODO THIS MIGHT FALL APART WITH LIQUID-COOLED CABINETS AS THOSE CAN BE USER SUPPLIED, but we don't currently support adding this with this tool
 
-		// Determine the current vlans in use by other cabinets
-		vlansInUse := map[int16]bool{}
-		for _, existingSubnet := range slsNetwork.Subnets {
-			vlansInUse[existingSubnet.VlanID] = true
-		}
-
 		// Now lest find the smallest free Vlan!
 		var vlanLow int16 = -1
 		var vlanHigh int16 = -1
@@ -208,7 +363,7 @@ func AllocateCabinetSubnet(networkName string, slsNetwork sls_common.NetworkExtr
 		}
 
 		for vlanCandidate := vlanLow; vlanCandidate <= vlanHigh; vlanCandidate++ {
-			if vlansInUse[vlanCandidate] {
+			if vlansInUse[vlanCandidate] || vlanRegistry.InUseByOtherNetwork(vlanCandidate, networkName) {
 				// currently in use
 				continue
 			}
@@ -216,46 +371,87 @@ func AllocateCabinetSubnet(networkName string, slsNetwork sls_common.NetworkExtr
 			vlan = vlanCandidate
 			break
 		}
+
+		if vlan == -1 {
+			return sls_common.IPV4Subnet{}, fmt.Errorf("failed to allocate VLAN for cabinet subnet (%s), the VLAN range %d-%d is full: %w", subnetName, vlanLow, vlanHigh, ErrNetworkExhausted)
+		}
 	}
 
-	if vlan == -1 {
-		return sls_common.IPV4Subnet{}, fmt.Errorf("failed to allocate VLAN for cabinet subnet (%s)", subnetName)
+	// Lay out the gateway and DHCP range according to the addressing plan
+	gateway, err := AdvanceIP(cabinetSubnet.Range().From(), addressingPlan.GatewayOffset)
+	if err != nil {
+		return sls_common.IPV4Subnet{}, fmt.Errorf("failed to determine gateway in CIDR (%s)", cabinetSubnet.String())
 	}
 
-	// DHCP starts 10 into the subnet
-	dhcpStart, err := AdvanceIP(cabinetSubnet.Range().From(), 10)
+	dhcpStart, err := AdvanceIP(cabinetSubnet.Range().From(), addressingPlan.DHCPStartOffset)
 	if err != nil {
 		return sls_common.IPV4Subnet{}, fmt.Errorf("failed to determine DHCP start in CIDR (%s)", cabinetSubnet.String())
 	}
 
+	subnetSize := uint32(1) << (32 - addressingPlan.PrefixLength)
+	dhcpEnd, err := AdvanceIP(cabinetSubnet.Range().From(), subnetSize-addressingPlan.DHCPEndReserve-1)
+	if err != nil {
+		return sls_common.IPV4Subnet{}, fmt.Errorf("failed to determine DHCP end in CIDR (%s)", cabinetSubnet.String())
+	}
+
 	return sls_common.IPV4Subnet{
 		Name:      subnetName,
 		CIDR:      cabinetSubnet.String(),
 		VlanID:    vlan,
-		Gateway:   cabinetSubnet.Range().From().Next().IPAddr().IP,
+		Gateway:   gateway.IPAddr().IP,
 		DHCPStart: dhcpStart.IPAddr().IP,
-		DHCPEnd:   cabinetSubnet.Range().To().Prior().IPAddr().IP,
+		DHCPEnd:   dhcpEnd.IPAddr().IP,
 	}, nil
 }
 
+// ReservationCommentInUseError is returned when the comment of a new IP reservation is already used by an IP
+// reservation with a different name.
+type ReservationCommentInUseError struct {
+	Comment string
+	Name    string
+}
+
+func (e *ReservationCommentInUseError) Error() string {
+	return fmt.Sprintf("ip reservation with comment (%v) already exits with name (%v)", e.Comment, e.Name)
+}
+
+// AllocateIP will allocate the next available IP in the subnet for the hardware with the given xname and alias, with
+// the xname used as the comment of the IP reservation.
 func AllocateIP(slsSubnet sls_common.IPV4Subnet, xname xnames.Xname, alias string) (sls_common.IPReservation, error) {
+	ipReservation, err := AllocateNamedIP(slsSubnet, alias, xname.String())
+
+	var commentInUse *ReservationCommentInUseError
+	if errors.As(err, &commentInUse) {
+		return sls_common.IPReservation{}, fmt.Errorf("ip reservation with xname (%v) already exits with name (%v)", xname.String(), commentInUse.Name)
+	}
+
+	return ipReservation, err
+}
+
+// AllocateNamedIP will allocate the next available IP in the subnet for an IP reservation with the given name and
+// comment. If an IP reservation with the same name and comment already exists, then an empty IP reservation is returned.
+func AllocateNamedIP(slsSubnet sls_common.IPV4Subnet, name, comment string) (sls_common.IPReservation, error) {
+	if name == "" {
+		return sls_common.IPReservation{}, fmt.Errorf("ip reservation name must not be empty")
+	}
+
 	ip, err := FindNextAvailableIP(slsSubnet)
 	if err != nil {
-		return sls_common.IPReservation{}, fmt.Errorf("failed to allocate ip for switch (%s) in subnet (%s)", xname.String(), slsSubnet.CIDR)
+		return sls_common.IPReservation{}, fmt.Errorf("failed to allocate ip for (%s) in subnet (%s): %w", name, slsSubnet.CIDR, err)
 	}
 
-	// Verify this switch is unique within the subnet
+	// Verify this reservation is unique within the subnet
 	for _, ipReservation := range slsSubnet.IPReservations {
-		matchingAlias := ipReservation.Name == alias
-		matchingXName := ipReservation.Comment == xname.String()
+		matchingName := ipReservation.Name == name
+		matchingComment := comment != "" && ipReservation.Comment == comment
 
-		if matchingAlias && matchingXName {
+		if matchingName && ipReservation.Comment == comment {
 			// IP reservation already exists
 			return sls_common.IPReservation{}, nil
-		} else if matchingAlias {
-			return sls_common.IPReservation{}, fmt.Errorf("ip reservation with name (%v) already exits on (%v)", alias, ipReservation.Comment)
-		} else if matchingXName {
-			return sls_common.IPReservation{}, fmt.Errorf("ip reservation with xname (%v) already exits with name (%v)", xname.String(), ipReservation.Name)
+		} else if matchingName {
+			return sls_common.IPReservation{}, fmt.Errorf("ip reservation with name (%v) already exits on (%v)", name, ipReservation.Comment)
+		} else if matchingComment {
+			return sls_common.IPReservation{}, &ReservationCommentInUseError{Comment: comment, Name: ipReservation.Name}
 		}
 	}
 
@@ -271,20 +467,78 @@ func AllocateIP(slsSubnet sls_common.IPV4Subnet, xname xnames.Xname, alias strin
 		}
 
 		if !ip.Less(dhcpStart) {
-			return sls_common.IPReservation{}, fmt.Errorf("ip reservation with xname (%v) and IP %s is outside the static IP address range - starting DHCP IP is %s", xname.String(), ip.String(), slsSubnet.DHCPStart.String())
+			return sls_common.IPReservation{}, fmt.Errorf("ip reservation (%v) with IP %s is outside the static IP address range - starting DHCP IP is %s", name, ip.String(), slsSubnet.DHCPStart.String())
 		}
 	}
 
 	return sls_common.IPReservation{
-		Comment:   xname.String(),
+		Comment:   comment,
 		IPAddress: ip.IPAddr().IP,
-		Name:      alias,
+		Name:      name,
 	}, nil
 }
 
-func FreeIPsInStaticRange(slsSubnet sls_common.IPV4Subnet) (uint32, error) {
-	// Probably need to steal some of the logic for allocate IP. Need to share the logic between the two
+// ReserveIP will add an IP reservation with the given name and comment to the subnet using the next available IP in
+// its static IP range. If the static IP range has no free IPs, then it is expanded by one IP. The returned bool is true
+// when the static IP range was expanded. If an identical IP reservation already exists, then the subnet is left
+// unchanged and an empty IP reservation is returned.
+func ReserveIP(slsSubnet *sls_common.IPV4Subnet, name, comment string) (sls_common.IPReservation, bool, error) {
+	for _, ipReservation := range slsSubnet.IPReservations {
+		if ipReservation.Name == name && ipReservation.Comment == comment {
+			return sls_common.IPReservation{}, false, nil
+		}
+	}
+
+	// Work on a copy, so the subnet is not left partially modified if the reservation cannot be made
+	subnet := *slsSubnet
+	subnet.IPReservations = append([]sls_common.IPReservation{}, slsSubnet.IPReservations...)
+
+	expanded := false
+	if subnet.DHCPStart != nil {
+		freeIPCount, err := FreeIPsInStaticRange(subnet)
+		if err != nil {
+			return sls_common.IPReservation{}, false, err
+		}
+
+		if freeIPCount == 0 {
+			if err := ExpandSubnetStaticRange(&subnet, 1); err != nil {
+				return sls_common.IPReservation{}, false, fmt.Errorf("unable to expand the static IP address range of subnet (%s): %w", subnet.Name, err)
+			}
+			expanded = true
+		}
+	}
+
+	ipReservation, err := AllocateNamedIP(subnet, name, comment)
+	if err != nil {
+		return sls_common.IPReservation{}, false, err
+	}
+
+	subnet.IPReservations = append(subnet.IPReservations, ipReservation)
+	*slsSubnet = subnet
+
+	return ipReservation, expanded, nil
+}
 
+// ReleaseIP will remove the IP reservation with the given name from the subnet, and return the removed IP reservation.
+// The static IP range of the subnet is left unchanged.
+func ReleaseIP(slsSubnet *sls_common.IPV4Subnet, name string) (sls_common.IPReservation, error) {
+	for i, ipReservation := range slsSubnet.IPReservations {
+		if ipReservation.Name != name {
+			continue
+		}
+
+		var ipReservations []sls_common.IPReservation
+		ipReservations = append(ipReservations, slsSubnet.IPReservations[:i]...)
+		ipReservations = append(ipReservations, slsSubnet.IPReservations[i+1:]...)
+		slsSubnet.IPReservations = ipReservations
+
+		return ipReservation, nil
+	}
+
+	return sls_common.IPReservation{}, fmt.Errorf("ip reservation with name (%s) does not exist in subnet (%s)", name, slsSubnet.Name)
+}
+
+func FreeIPsInStaticRange(slsSubnet sls_common.IPV4Subnet) (uint32, error) {
 	subnet, err := netaddr.ParseIPPrefix(slsSubnet.CIDR)
 	if err != nil {
 		return 0, fmt.Errorf("failed to parse subnet CIDR (%v): %w", slsSubnet.CIDR, err)
@@ -295,22 +549,32 @@ func FreeIPsInStaticRange(slsSubnet sls_common.IPV4Subnet) (uint32, error) {
 		return 0, err
 	}
 
-	startingIP := subnet.Range().From().Next() // Start at the first usable available IP in the subnet.
-	endingIP, ok := netaddr.FromStdIP(slsSubnet.DHCPStart)
+	dhcpStart, ok := netaddr.FromStdIP(slsSubnet.DHCPStart)
 	if !ok {
 		return 0, fmt.Errorf("failed to convert DHCP Start IP address to netaddr struct")
 	}
 
-	var count uint32
-	for ip := startingIP; ip.Less(endingIP); ip = ip.Next() {
-		if existingIPAddressesSet.Contains(ip) {
-			// IP address currently in use
-			continue
+	// The static range starts at the first usable IP in the subnet, and ends right before the DHCP range.
+	staticRange := netaddr.IPRangeFrom(subnet.Range().From().Next(), dhcpStart.Prior())
+	if !staticRange.IsValid() {
+		return 0, nil
+	}
+
+	freeIPs, err := subtractIPSet(staticRange, existingIPAddressesSet)
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	for _, freeRange := range freeIPs.Ranges() {
+		count += ipRangeSize(freeRange)
+		if count > math.MaxUint32 {
+			// IPv6 subnets can contain more free IPs than can be counted
+			return math.MaxUint32, nil
 		}
-		count++
 	}
 
-	return count, nil
+	return uint32(count), nil
 }
 
 func ExpandSubnetStaticRange(slsSubnet *sls_common.IPV4Subnet, count uint32) error {
@@ -336,7 +600,7 @@ func ExpandSubnetStaticRange(slsSubnet *sls_common.IPV4Subnet, count uint32) err
 
 	// Verify the DHCP Start address is smaller than the end address
 	if !dhcpStart.Less(dhcpEnd) {
-		return fmt.Errorf("new DHCP Start address %v is equal or larger then the DHCP End address %v", dhcpStart, dhcpEnd)
+		return fmt.Errorf("%w, new DHCP Start address %v is equal or larger then the DHCP End address %v", ErrSubnetExhausted, dhcpStart, dhcpEnd)
 	}
 
 	// Now update the SLS subnet
@@ -21,3 +21,547 @@
 // OTHER DEALINGS IN THE SOFTWARE.
 
 package ipam
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/Cray-HPE/hms-xname/xnames"
+	"github.com/stretchr/testify/suite"
+	"inet.af/netaddr"
+)
+
+type AllocateCabinetSubnetTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AllocateCabinetSubnetTestSuite) hmnRVR() sls_common.NetworkExtraProperties {
+	return sls_common.NetworkExtraProperties{
+		CIDR: "10.107.0.0/17",
+		Subnets: []sls_common.IPV4Subnet{
+			{
+				Name:   "cabinet_3000",
+				CIDR:   "10.107.0.0/22",
+				VlanID: 1513,
+			},
+		},
+	}
+}
+
+func (suite *AllocateCabinetSubnetTestSuite) TestDefaultAddressingPlan() {
+	subnet, err := AllocateCabinetSubnet("HMN_RVR", suite.hmnRVR(), xnames.Cabinet{Cabinet: 3001}, nil, nil, configs.SubnetAddressingPlan{}, nil)
+	suite.NoError(err)
+
+	suite.Equal("cabinet_3001", subnet.Name)
+	suite.Equal("10.107.4.0/22", subnet.CIDR)
+	suite.Equal(int16(1514), subnet.VlanID)
+	suite.Equal(net.ParseIP("10.107.4.1").To4(), subnet.Gateway)
+	suite.Equal(net.ParseIP("10.107.4.10").To4(), subnet.DHCPStart)
+	suite.Equal(net.ParseIP("10.107.7.254").To4(), subnet.DHCPEnd)
+}
+
+func (suite *AllocateCabinetSubnetTestSuite) TestCustomAddressingPlan() {
+	addressingPlan := configs.SubnetAddressingPlan{
+		PrefixLength:    24,
+		GatewayOffset:   1,
+		DHCPStartOffset: 50,
+		DHCPEndReserve:  5,
+	}
+
+	subnet, err := AllocateCabinetSubnet("HMN_RVR", suite.hmnRVR(), xnames.Cabinet{Cabinet: 3001}, nil, nil, addressingPlan, nil)
+	suite.NoError(err)
+
+	suite.Equal("10.107.4.0/24", subnet.CIDR)
+	suite.Equal(net.ParseIP("10.107.4.1").To4(), subnet.Gateway)
+	suite.Equal(net.ParseIP("10.107.4.50").To4(), subnet.DHCPStart)
+	suite.Equal(net.ParseIP("10.107.4.250").To4(), subnet.DHCPEnd)
+}
+
+func (suite *AllocateCabinetSubnetTestSuite) TestInvalidAddressingPlan() {
+	_, err := AllocateCabinetSubnet("HMN_RVR", suite.hmnRVR(), xnames.Cabinet{Cabinet: 3001}, nil, nil, configs.SubnetAddressingPlan{PrefixLength: 16}, nil)
+	suite.EqualError(err, "invalid addressing plan for network (HMN_RVR): subnet prefix length /16 is larger than the network CIDR (10.107.0.0/17)")
+}
+
+func (suite *AllocateCabinetSubnetTestSuite) TestOverrides() {
+	vlanOverride := int16(1600)
+	cidrOverride := netaddr.MustParseIPPrefix("10.107.8.0/24")
+
+	subnet, err := AllocateCabinetSubnet("HMN_RVR", suite.hmnRVR(), xnames.Cabinet{Cabinet: 3001}, &vlanOverride, &cidrOverride, configs.SubnetAddressingPlan{}, nil)
+	suite.NoError(err)
+
+	suite.Equal("10.107.8.0/24", subnet.CIDR)
+	suite.Equal(int16(1600), subnet.VlanID)
+	suite.Equal(net.ParseIP("10.107.8.1").To4(), subnet.Gateway)
+	suite.Equal(net.ParseIP("10.107.8.10").To4(), subnet.DHCPStart)
+	suite.Equal(net.ParseIP("10.107.8.254").To4(), subnet.DHCPEnd)
+}
+
+func (suite *AllocateCabinetSubnetTestSuite) TestOverrides_VLANInUse() {
+	vlanOverride := int16(1513)
+
+	_, err := AllocateCabinetSubnet("HMN_RVR", suite.hmnRVR(), xnames.Cabinet{Cabinet: 3001}, &vlanOverride, nil, configs.SubnetAddressingPlan{}, nil)
+	suite.EqualError(err, "VLAN (1513) is already in use within network (HMN_RVR)")
+
+	var overrideErr *OverrideError
+	suite.ErrorAs(err, &overrideErr)
+}
+
+func (suite *AllocateCabinetSubnetTestSuite) TestOverrides_OverlappingCIDR() {
+	cidrOverride := netaddr.MustParseIPPrefix("10.107.2.0/24")
+
+	_, err := AllocateCabinetSubnet("HMN_RVR", suite.hmnRVR(), xnames.Cabinet{Cabinet: 3001}, nil, &cidrOverride, configs.SubnetAddressingPlan{}, nil)
+	suite.EqualError(err, "unable to use subnet (10.107.2.0/24) for (x3001): subnet overlaps with existing subnet (cabinet_3000) with CIDR (10.107.0.0/22)")
+
+	var overrideErr *OverrideError
+	suite.ErrorAs(err, &overrideErr)
+}
+
+func (suite *AllocateCabinetSubnetTestSuite) TestOverrides_CIDROutsideNetwork() {
+	cidrOverride := netaddr.MustParseIPPrefix("10.108.0.0/22")
+
+	_, err := AllocateCabinetSubnet("HMN_RVR", suite.hmnRVR(), xnames.Cabinet{Cabinet: 3001}, nil, &cidrOverride, configs.SubnetAddressingPlan{}, nil)
+	suite.EqualError(err, "unable to use subnet (10.108.0.0/22) for (x3001): subnet is not contained within the network CIDR (10.107.0.0/17)")
+}
+
+func (suite *AllocateCabinetSubnetTestSuite) TestSkipVLANsUsedByOtherNetworks() {
+	vlanRegistry := VLANRegistry{}
+	vlanRegistry.Add(1514, VLANUsage{Network: "CAN", Subnet: "bootstrap_dhcp"})
+
+	subnet, err := AllocateCabinetSubnet("HMN_RVR", suite.hmnRVR(), xnames.Cabinet{Cabinet: 3001}, nil, nil, configs.SubnetAddressingPlan{}, vlanRegistry)
+	suite.NoError(err)
+	suite.Equal(int16(1515), subnet.VlanID)
+}
+
+func (suite *AllocateCabinetSubnetTestSuite) TestOverrides_VLANInUseByOtherNetwork() {
+	vlanRegistry := VLANRegistry{}
+	vlanRegistry.Add(1600, VLANUsage{Network: "NMN_RVR", Subnet: "cabinet_3000"})
+	vlanOverride := int16(1600)
+
+	_, err := AllocateCabinetSubnet("HMN_RVR", suite.hmnRVR(), xnames.Cabinet{Cabinet: 3001}, &vlanOverride, nil, configs.SubnetAddressingPlan{}, vlanRegistry)
+	suite.EqualError(err, "VLAN (1600) is already in use by other networks [NMN_RVR]")
+}
+
+func (suite *AllocateCabinetSubnetTestSuite) TestVLANRangeExhausted() {
+	vlanRegistry := VLANRegistry{}
+	for vlan := int16(1514); vlan <= 1769; vlan++ {
+		vlanRegistry.Add(vlan, VLANUsage{Network: "CAN", Subnet: "bootstrap_dhcp"})
+	}
+
+	_, err := AllocateCabinetSubnet("HMN_RVR", suite.hmnRVR(), xnames.Cabinet{Cabinet: 3001}, nil, nil, configs.SubnetAddressingPlan{}, vlanRegistry)
+	suite.EqualError(err, "failed to allocate VLAN for cabinet subnet (cabinet_3001), the VLAN range 1513-1769 is full: network space has been exhausted")
+	suite.ErrorIs(err, ErrNetworkExhausted)
+}
+
+func TestAllocateCabinetSubnetTestSuite(t *testing.T) {
+	suite.Run(t, new(AllocateCabinetSubnetTestSuite))
+}
+
+type FindNextAvailableSubnetTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FindNextAvailableSubnetTestSuite) TestSmallerSubnetsThanExisting() {
+	slsNetwork := sls_common.NetworkExtraProperties{
+		CIDR: "10.107.0.0/17",
+		Subnets: []sls_common.IPV4Subnet{
+			{CIDR: "10.107.0.0/22"},
+		},
+	}
+
+	subnet, err := FindNextAvailableSubnet(slsNetwork, 24)
+	suite.NoError(err)
+	suite.Equal(netaddr.MustParseIPPrefix("10.107.4.0/24"), subnet)
+}
+
+func (suite *FindNextAvailableSubnetTestSuite) TestLargerSubnetsThanExisting() {
+	// The second half of the first /23 is in use, so the first /23 can not be used.
+	slsNetwork := sls_common.NetworkExtraProperties{
+		CIDR: "10.107.0.0/17",
+		Subnets: []sls_common.IPV4Subnet{
+			{CIDR: "10.107.1.0/24"},
+		},
+	}
+
+	subnet, err := FindNextAvailableSubnet(slsNetwork, 23)
+	suite.NoError(err)
+	suite.Equal(netaddr.MustParseIPPrefix("10.107.2.0/23"), subnet)
+}
+
+func (suite *FindNextAvailableSubnetTestSuite) TestExhausted() {
+	slsNetwork := sls_common.NetworkExtraProperties{
+		CIDR: "10.107.0.0/22",
+		Subnets: []sls_common.IPV4Subnet{
+			{CIDR: "10.107.0.0/22"},
+		},
+	}
+
+	_, err := FindNextAvailableSubnet(slsNetwork, 24)
+	suite.EqualError(err, "network space has been exhausted")
+	suite.ErrorIs(err, ErrNetworkExhausted)
+}
+
+func TestFindNextAvailableSubnetTestSuite(t *testing.T) {
+	suite.Run(t, new(FindNextAvailableSubnetTestSuite))
+}
+
+type AdvanceIPTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AdvanceIPTestSuite) TestAdvance() {
+	ip, err := AdvanceIP(netaddr.MustParseIP("10.107.0.250"), 10)
+	suite.NoError(err)
+	suite.Equal(netaddr.MustParseIP("10.107.1.4"), ip)
+}
+
+func (suite *AdvanceIPTestSuite) TestAdvanceToLastIP() {
+	ip, err := AdvanceIP(netaddr.MustParseIP("255.255.255.250"), 5)
+	suite.NoError(err)
+	suite.Equal(netaddr.MustParseIP("255.255.255.255"), ip)
+}
+
+func (suite *AdvanceIPTestSuite) TestOverflow() {
+	_, err := AdvanceIP(netaddr.MustParseIP("255.255.255.250"), 6)
+	suite.EqualError(err, "advancing IP (255.255.255.250) by 6 overflows the IPv4 address space")
+}
+
+func TestAdvanceIPTestSuite(t *testing.T) {
+	suite.Run(t, new(AdvanceIPTestSuite))
+}
+
+type SplitNetworkTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SplitNetworkTestSuite) TestSplit() {
+	subnets, err := SplitNetwork(netaddr.MustParseIPPrefix("10.107.0.0/22"), 24)
+	suite.NoError(err)
+	suite.Equal([]netaddr.IPPrefix{
+		netaddr.MustParseIPPrefix("10.107.0.0/24"),
+		netaddr.MustParseIPPrefix("10.107.1.0/24"),
+		netaddr.MustParseIPPrefix("10.107.2.0/24"),
+		netaddr.MustParseIPPrefix("10.107.3.0/24"),
+	}, subnets)
+}
+
+func (suite *SplitNetworkTestSuite) TestEndOfAddressSpace() {
+	subnets, err := SplitNetwork(netaddr.MustParseIPPrefix("255.255.254.0/23"), 24)
+	suite.NoError(err)
+	suite.Equal([]netaddr.IPPrefix{
+		netaddr.MustParseIPPrefix("255.255.254.0/24"),
+		netaddr.MustParseIPPrefix("255.255.255.0/24"),
+	}, subnets)
+}
+
+func (suite *SplitNetworkTestSuite) TestSubnetLargerThanNetwork() {
+	_, err := SplitNetwork(netaddr.MustParseIPPrefix("10.107.0.0/24"), 22)
+	suite.EqualError(err, "invalid subnet mask provided /22")
+}
+
+func TestSplitNetworkTestSuite(t *testing.T) {
+	suite.Run(t, new(SplitNetworkTestSuite))
+}
+
+type FindNextAvailableIPTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FindNextAvailableIPTestSuite) TestSkipReservations() {
+	slsSubnet := sls_common.IPV4Subnet{
+		CIDR:    "10.107.0.0/24",
+		Gateway: net.ParseIP("10.107.0.1"),
+		IPReservations: []sls_common.IPReservation{
+			{Name: "sw-leaf-bmc-001", IPAddress: net.ParseIP("10.107.0.2")},
+			{Name: "sw-leaf-bmc-002", IPAddress: net.ParseIP("10.107.0.3")},
+			{Name: "sw-leaf-bmc-003", IPAddress: net.ParseIP("10.107.0.5")},
+		},
+	}
+
+	ip, err := FindNextAvailableIP(slsSubnet)
+	suite.NoError(err)
+	suite.Equal(netaddr.MustParseIP("10.107.0.4"), ip)
+}
+
+func (suite *FindNextAvailableIPTestSuite) TestFull() {
+	slsSubnet := sls_common.IPV4Subnet{
+		CIDR:    "10.107.0.0/30",
+		Gateway: net.ParseIP("10.107.0.1"),
+		IPReservations: []sls_common.IPReservation{
+			{Name: "sw-leaf-bmc-001", IPAddress: net.ParseIP("10.107.0.2")},
+		},
+	}
+
+	_, err := FindNextAvailableIP(slsSubnet)
+	suite.EqualError(err, "subnet has no available IPs")
+}
+
+func TestFindNextAvailableIPTestSuite(t *testing.T) {
+	suite.Run(t, new(FindNextAvailableIPTestSuite))
+}
+
+type FreeIPsInStaticRangeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FreeIPsInStaticRangeTestSuite) TestCount() {
+	slsSubnet := sls_common.IPV4Subnet{
+		CIDR:      "10.107.0.0/24",
+		Gateway:   net.ParseIP("10.107.0.1"),
+		DHCPStart: net.ParseIP("10.107.0.10"),
+		DHCPEnd:   net.ParseIP("10.107.0.254"),
+		IPReservations: []sls_common.IPReservation{
+			{Name: "sw-leaf-bmc-001", IPAddress: net.ParseIP("10.107.0.2")},
+			{Name: "uan01", IPAddress: net.ParseIP("10.107.0.20")},
+		},
+	}
+
+	// 10.107.0.1 through 10.107.0.9 minus the gateway and one reservation within the static range
+	count, err := FreeIPsInStaticRange(slsSubnet)
+	suite.NoError(err)
+	suite.Equal(uint32(7), count)
+}
+
+func (suite *FreeIPsInStaticRangeTestSuite) TestNoStaticRange() {
+	slsSubnet := sls_common.IPV4Subnet{
+		CIDR:      "10.107.0.0/24",
+		Gateway:   net.ParseIP("10.107.0.1"),
+		DHCPStart: net.ParseIP("10.107.0.1"),
+		DHCPEnd:   net.ParseIP("10.107.0.254"),
+	}
+
+	count, err := FreeIPsInStaticRange(slsSubnet)
+	suite.NoError(err)
+	suite.Equal(uint32(0), count)
+}
+
+func TestFreeIPsInStaticRangeTestSuite(t *testing.T) {
+	suite.Run(t, new(FreeIPsInStaticRangeTestSuite))
+}
+
+type ReserveIPTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ReserveIPTestSuite) bootstrapDHCP() sls_common.IPV4Subnet {
+	return sls_common.IPV4Subnet{
+		Name:      "bootstrap_dhcp",
+		CIDR:      "10.103.6.0/24",
+		Gateway:   net.ParseIP("10.103.6.1"),
+		DHCPStart: net.ParseIP("10.103.6.4"),
+		DHCPEnd:   net.ParseIP("10.103.6.254"),
+		IPReservations: []sls_common.IPReservation{
+			{Name: "ncn-m001", IPAddress: net.ParseIP("10.103.6.2")},
+		},
+	}
+}
+
+func (suite *ReserveIPTestSuite) TestReserve() {
+	slsSubnet := suite.bootstrapDHCP()
+
+	ipReservation, expanded, err := ReserveIP(&slsSubnet, "customer-edge-vip", "")
+	suite.NoError(err)
+	suite.False(expanded)
+	suite.Equal(sls_common.IPReservation{Name: "customer-edge-vip", IPAddress: net.ParseIP("10.103.6.3").To4()}, ipReservation)
+	suite.Len(slsSubnet.IPReservations, 2)
+	suite.Equal("10.103.6.4", slsSubnet.DHCPStart.String())
+}
+
+func (suite *ReserveIPTestSuite) TestReserveExpandsStaticRange() {
+	slsSubnet := suite.bootstrapDHCP()
+	slsSubnet.IPReservations = append(slsSubnet.IPReservations, sls_common.IPReservation{Name: "ncn-m002", IPAddress: net.ParseIP("10.103.6.3")})
+
+	ipReservation, expanded, err := ReserveIP(&slsSubnet, "uan01", "x3000c0s19b0n0")
+	suite.NoError(err)
+	suite.True(expanded)
+	suite.Equal(sls_common.IPReservation{Name: "uan01", Comment: "x3000c0s19b0n0", IPAddress: net.ParseIP("10.103.6.4").To4()}, ipReservation)
+	suite.Len(slsSubnet.IPReservations, 3)
+	suite.Equal("10.103.6.5", slsSubnet.DHCPStart.String())
+}
+
+func (suite *ReserveIPTestSuite) TestAlreadyReserved() {
+	slsSubnet := suite.bootstrapDHCP()
+
+	ipReservation, expanded, err := ReserveIP(&slsSubnet, "ncn-m001", "")
+	suite.NoError(err)
+	suite.False(expanded)
+	suite.Equal(sls_common.IPReservation{}, ipReservation)
+	suite.Equal(suite.bootstrapDHCP(), slsSubnet)
+}
+
+func (suite *ReserveIPTestSuite) TestNameInUse() {
+	slsSubnet := suite.bootstrapDHCP()
+
+	_, _, err := ReserveIP(&slsSubnet, "ncn-m001", "x3000c0s1b0n0")
+	suite.EqualError(err, "ip reservation with name (ncn-m001) already exits on ()")
+	suite.Equal(suite.bootstrapDHCP(), slsSubnet)
+}
+
+func (suite *ReserveIPTestSuite) TestCommentInUse() {
+	slsSubnet := suite.bootstrapDHCP()
+	slsSubnet.IPReservations[0].Comment = "x3000c0s1b0n0"
+
+	_, _, err := ReserveIP(&slsSubnet, "uan01", "x3000c0s1b0n0")
+	suite.EqualError(err, "ip reservation with comment (x3000c0s1b0n0) already exits with name (ncn-m001)")
+}
+
+func (suite *ReserveIPTestSuite) TestAllocateIPXnameInUse() {
+	slsSubnet := suite.bootstrapDHCP()
+	slsSubnet.IPReservations[0].Comment = "x3000c0s1b0n0"
+
+	_, err := AllocateIP(slsSubnet, xnames.FromString("x3000c0s1b0n0"), "uan01")
+	suite.EqualError(err, "ip reservation with xname (x3000c0s1b0n0) already exits with name (ncn-m001)")
+}
+
+func (suite *ReserveIPTestSuite) TestUnableToExpand() {
+	slsSubnet := suite.bootstrapDHCP()
+	slsSubnet.IPReservations = append(slsSubnet.IPReservations, sls_common.IPReservation{Name: "ncn-m002", IPAddress: net.ParseIP("10.103.6.3")})
+	slsSubnet.DHCPEnd = net.ParseIP("10.103.6.5")
+
+	_, _, err := ReserveIP(&slsSubnet, "uan01", "")
+	suite.EqualError(err, "unable to expand the static IP address range of subnet (bootstrap_dhcp): subnet has no available IPs, new DHCP Start address 10.103.6.5 is equal or larger then the DHCP End address 10.103.6.5")
+	suite.ErrorIs(err, ErrSubnetExhausted)
+	suite.Equal("10.103.6.4", slsSubnet.DHCPStart.String())
+}
+
+func (suite *ReserveIPTestSuite) TestRelease() {
+	slsSubnet := suite.bootstrapDHCP()
+
+	ipReservation, err := ReleaseIP(&slsSubnet, "ncn-m001")
+	suite.NoError(err)
+	suite.Equal("ncn-m001", ipReservation.Name)
+	suite.Empty(slsSubnet.IPReservations)
+	suite.Equal("10.103.6.4", slsSubnet.DHCPStart.String())
+}
+
+func (suite *ReserveIPTestSuite) TestReleaseMissing() {
+	slsSubnet := suite.bootstrapDHCP()
+
+	_, err := ReleaseIP(&slsSubnet, "uan01")
+	suite.EqualError(err, "ip reservation with name (uan01) does not exist in subnet (bootstrap_dhcp)")
+	suite.Len(slsSubnet.IPReservations, 1)
+}
+
+func TestReserveIPTestSuite(t *testing.T) {
+	suite.Run(t, new(ReserveIPTestSuite))
+}
+
+// The following benchmarks allocate within networks of different sizes with the same amount of existing allocations,
+// as the cost of allocation should depend on the number of existing allocations and not the size of the network.
+
+func BenchmarkFindNextAvailableSubnet(b *testing.B) {
+	for _, networkCIDR := range []string{"10.0.0.0/16", "10.0.0.0/12", "10.0.0.0/8"} {
+		network := netaddr.MustParseIPPrefix(networkCIDR)
+
+		// Fill the start of the network with 60 /22 cabinet subnets
+		subnets, err := SplitNetwork(netaddr.IPPrefixFrom(network.IP(), 16), 22)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		slsNetwork := sls_common.NetworkExtraProperties{CIDR: networkCIDR}
+		for i, subnet := range subnets[:60] {
+			slsNetwork.Subnets = append(slsNetwork.Subnets, sls_common.IPV4Subnet{
+				Name: fmt.Sprintf("cabinet_%d", 3000+i),
+				CIDR: subnet.String(),
+			})
+		}
+
+		b.Run(networkCIDR, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := FindNextAvailableSubnet(slsNetwork, 22); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFindNextAvailableIP(b *testing.B) {
+	for _, subnetCIDR := range []string{"10.0.0.0/24", "10.0.0.0/20", "10.0.0.0/16"} {
+		subnet := netaddr.MustParseIPPrefix(subnetCIDR)
+
+		// Reserve the first 250 IPs of the subnet
+		slsSubnet := sls_common.IPV4Subnet{
+			CIDR:    subnetCIDR,
+			Gateway: subnet.IP().Next().IPAddr().IP,
+		}
+		ip := subnet.IP().Next().Next()
+		for i := 0; i < 250; i++ {
+			slsSubnet.IPReservations = append(slsSubnet.IPReservations, sls_common.IPReservation{
+				Name:      fmt.Sprintf("reservation_%d", i),
+				IPAddress: ip.IPAddr().IP,
+			})
+			ip = ip.Next()
+		}
+
+		b.Run(subnetCIDR, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := FindNextAvailableIP(slsSubnet); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFreeIPsInStaticRange(b *testing.B) {
+	for _, subnetCIDR := range []string{"10.0.0.0/24", "10.0.0.0/20", "10.0.0.0/16"} {
+		subnet := netaddr.MustParseIPPrefix(subnetCIDR)
+
+		// The static range covers all but the last few IPs in the subnet
+		dhcpStart := subnet.Range().To().Prior().Prior()
+		slsSubnet := sls_common.IPV4Subnet{
+			CIDR:      subnetCIDR,
+			Gateway:   subnet.IP().Next().IPAddr().IP,
+			DHCPStart: dhcpStart.IPAddr().IP,
+			DHCPEnd:   subnet.Range().To().Prior().IPAddr().IP,
+		}
+
+		b.Run(subnetCIDR, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := FreeIPsInStaticRange(slsSubnet); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func (suite *AdvanceIPTestSuite) TestAdvanceIPv6() {
+	ip, err := AdvanceIP(netaddr.MustParseIP("fd00::ffff"), 2)
+	suite.NoError(err)
+	suite.Equal(netaddr.MustParseIP("fd00::1:1"), ip)
+}
+
+func (suite *AdvanceIPTestSuite) TestOverflowIPv6() {
+	_, err := AdvanceIP(netaddr.MustParseIP("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"), 1)
+	suite.EqualError(err, "advancing IP (ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff) by 1 overflows the IPv6 address space")
+}
+
+func (suite *SplitNetworkTestSuite) TestSplitIPv6() {
+	subnets, err := SplitNetwork(netaddr.MustParseIPPrefix("fd00:0:0:10::/62"), 64)
+	suite.NoError(err)
+	suite.Equal([]netaddr.IPPrefix{
+		netaddr.MustParseIPPrefix("fd00:0:0:10::/64"),
+		netaddr.MustParseIPPrefix("fd00:0:0:11::/64"),
+		netaddr.MustParseIPPrefix("fd00:0:0:12::/64"),
+		netaddr.MustParseIPPrefix("fd00:0:0:13::/64"),
+	}, subnets)
+}
+
+func (suite *FindNextAvailableIPTestSuite) TestIPv6() {
+	slsSubnet := sls_common.IPV4Subnet{
+		CIDR:    "fd00:0:0:10::/64",
+		Gateway: net.ParseIP("fd00:0:0:10::1"),
+		IPReservations: []sls_common.IPReservation{
+			{Name: "sw-leaf-bmc-001", IPAddress: net.ParseIP("fd00:0:0:10::2")},
+		},
+	}
+
+	ip, err := FindNextAvailableIP(slsSubnet)
+	suite.NoError(err)
+	suite.Equal(netaddr.MustParseIP("fd00:0:0:10::3"), ip)
+}
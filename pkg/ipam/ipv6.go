@@ -0,0 +1,114 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package ipam
+
+import (
+	"fmt"
+	"math/big"
+
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"inet.af/netaddr"
+)
+
+// AllocateIPv6Prefix will carve the lowest available prefix of the given length out of the IPv6 network, skipping any
+// prefixes already in use.
+func AllocateIPv6Prefix(network netaddr.IPPrefix, prefixesInUse []netaddr.IPPrefix, prefixLength uint8) (netaddr.IPPrefix, error) {
+	if !network.IP().Is6() {
+		return netaddr.IPPrefix{}, fmt.Errorf("network (%s) is not an IPv6 network", network)
+	}
+	network = network.Masked()
+
+	if err := validateSubnetMask(network, prefixLength); err != nil {
+		return netaddr.IPPrefix{}, err
+	}
+
+	var inUse netaddr.IPSetBuilder
+	for _, prefix := range prefixesInUse {
+		inUse.AddPrefix(prefix.Masked())
+	}
+	inUseSet, err := inUse.IPSet()
+	if err != nil {
+		return netaddr.IPPrefix{}, err
+	}
+
+	availableSpace, err := subtractIPSet(network.Range(), inUseSet)
+	if err != nil {
+		return netaddr.IPPrefix{}, err
+	}
+
+	prefix, _, ok := availableSpace.RemoveFreePrefix(prefixLength)
+	if !ok {
+		return netaddr.IPPrefix{}, ErrNetworkExhausted
+	}
+
+	return prefix, nil
+}
+
+// MapIPv4ToIPv6 will map an IPv4 address to the address at the same offset within the IPv6 network as the IPv4 address
+// is within the IPv4 network. This allows the IPv6 addresses of a dual-stack network to mirror the existing IPv4
+// reservations, without needing to keep track of them separately.
+func MapIPv4ToIPv6(ipv4Network netaddr.IPPrefix, ip netaddr.IP, ipv6Network netaddr.IPPrefix) (netaddr.IP, error) {
+	if !ipv4Network.IP().Is4() || !ip.Is4() {
+		return netaddr.IP{}, fmt.Errorf("IP (%s) and network (%s) must be IPv4", ip, ipv4Network)
+	}
+	if !ipv6Network.IP().Is6() {
+		return netaddr.IP{}, fmt.Errorf("network (%s) is not an IPv6 network", ipv6Network)
+	}
+
+	ipv4Network = ipv4Network.Masked()
+	ipv6Network = ipv6Network.Masked()
+	if !ipv4Network.Contains(ip) {
+		return netaddr.IP{}, fmt.Errorf("IP (%s) is not within network (%s)", ip, ipv4Network)
+	}
+
+	// Every IPv4 address of the IPv4 network needs to fit in the IPv6 network
+	if 128-int(ipv6Network.Bits()) < 32-int(ipv4Network.Bits()) {
+		return netaddr.IP{}, fmt.Errorf("IPv6 network (%s) is smaller than IPv4 network (%s)", ipv6Network, ipv4Network)
+	}
+
+	offset := ipv4ToUint32(ip) - ipv4ToUint32(ipv4Network.IP())
+
+	ipv6Bytes := ipv6Network.IP().As16()
+	ipv6Raw := new(big.Int).SetBytes(ipv6Bytes[:])
+	ipv6Raw.Add(ipv6Raw, new(big.Int).SetUint64(uint64(offset)))
+	ipv6Raw.FillBytes(ipv6Bytes[:])
+
+	return netaddr.IPv6Raw(ipv6Bytes), nil
+}
+
+// IPv6Reservation will build the IPv6 counterpart of an IPv4 reservation within a dual-stack network.
+func IPv6Reservation(ipv4Network netaddr.IPPrefix, ipReservation sls_common.IPReservation, ipv6Network netaddr.IPPrefix) (sls_common.IPReservation, error) {
+	ip, ok := netaddr.FromStdIP(ipReservation.IPAddress)
+	if !ok {
+		return sls_common.IPReservation{}, fmt.Errorf("failed to parse IPReservation IP (%v)", ipReservation.IPAddress)
+	}
+
+	ipv6, err := MapIPv4ToIPv6(ipv4Network, ip, ipv6Network)
+	if err != nil {
+		return sls_common.IPReservation{}, fmt.Errorf("unable to determine IPv6 address for (%s): %w", ipReservation.Name, err)
+	}
+
+	ipv6Reservation := ipReservation
+	ipv6Reservation.IPAddress = ipv6.IPAddr().IP
+	return ipv6Reservation, nil
+}
@@ -0,0 +1,109 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package ipam
+
+import (
+	"net"
+	"testing"
+
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+	"inet.af/netaddr"
+)
+
+type IPv6TestSuite struct {
+	suite.Suite
+}
+
+func (suite *IPv6TestSuite) TestAllocateIPv6Prefix() {
+	prefixesInUse := []netaddr.IPPrefix{
+		netaddr.MustParseIPPrefix("fd00:0:0:100::/64"),
+		netaddr.MustParseIPPrefix("fd00:0:0:101::/64"),
+	}
+
+	prefix, err := AllocateIPv6Prefix(netaddr.MustParseIPPrefix("fd00:0:0:100::/56"), prefixesInUse, 64)
+	suite.NoError(err)
+	suite.Equal(netaddr.MustParseIPPrefix("fd00:0:0:102::/64"), prefix)
+}
+
+func (suite *IPv6TestSuite) TestAllocateIPv6Prefix_Exhausted() {
+	prefixesInUse := []netaddr.IPPrefix{
+		netaddr.MustParseIPPrefix("fd00:0:0:100::/63"),
+	}
+
+	_, err := AllocateIPv6Prefix(netaddr.MustParseIPPrefix("fd00:0:0:100::/63"), prefixesInUse, 64)
+	suite.EqualError(err, "network space has been exhausted")
+}
+
+func (suite *IPv6TestSuite) TestAllocateIPv6Prefix_IPv4Network() {
+	_, err := AllocateIPv6Prefix(netaddr.MustParseIPPrefix("10.107.0.0/17"), nil, 64)
+	suite.EqualError(err, "network (10.107.0.0/17) is not an IPv6 network")
+}
+
+func (suite *IPv6TestSuite) TestMapIPv4ToIPv6() {
+	ip, err := MapIPv4ToIPv6(
+		netaddr.MustParseIPPrefix("10.254.0.0/17"),
+		netaddr.MustParseIP("10.254.1.5"),
+		netaddr.MustParseIPPrefix("fd00:0:0:4::/64"),
+	)
+	suite.NoError(err)
+	suite.Equal(netaddr.MustParseIP("fd00:0:0:4::105"), ip)
+}
+
+func (suite *IPv6TestSuite) TestMapIPv4ToIPv6_OutsideNetwork() {
+	_, err := MapIPv4ToIPv6(
+		netaddr.MustParseIPPrefix("10.254.0.0/17"),
+		netaddr.MustParseIP("10.252.1.5"),
+		netaddr.MustParseIPPrefix("fd00:0:0:4::/64"),
+	)
+	suite.EqualError(err, "IP (10.252.1.5) is not within network (10.254.0.0/17)")
+}
+
+func (suite *IPv6TestSuite) TestMapIPv4ToIPv6_IPv6NetworkTooSmall() {
+	_, err := MapIPv4ToIPv6(
+		netaddr.MustParseIPPrefix("10.254.0.0/17"),
+		netaddr.MustParseIP("10.254.1.5"),
+		netaddr.MustParseIPPrefix("fd00:0:0:4::/120"),
+	)
+	suite.EqualError(err, "IPv6 network (fd00:0:0:4::/120) is smaller than IPv4 network (10.254.0.0/17)")
+}
+
+func (suite *IPv6TestSuite) TestIPv6Reservation() {
+	ipReservation := sls_common.IPReservation{
+		Name:      "sw-leaf-bmc-001",
+		IPAddress: net.ParseIP("10.254.0.2"),
+		Comment:   "x3000c0w14",
+	}
+
+	ipv6Reservation, err := IPv6Reservation(netaddr.MustParseIPPrefix("10.254.0.0/17"), ipReservation, netaddr.MustParseIPPrefix("fd00:0:0:4::/64"))
+	suite.NoError(err)
+	suite.Equal(sls_common.IPReservation{
+		Name:      "sw-leaf-bmc-001",
+		IPAddress: net.ParseIP("fd00:0:0:4::2"),
+		Comment:   "x3000c0w14",
+	}, ipv6Reservation)
+}
+
+func TestIPv6TestSuite(t *testing.T) {
+	suite.Run(t, new(IPv6TestSuite))
+}
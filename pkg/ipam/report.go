@@ -0,0 +1,331 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package ipam
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"inet.af/netaddr"
+)
+
+// SubnetUtilization describes how much of a subnet is in use.
+type SubnetUtilization struct {
+	Name string
+	CIDR string
+
+	// Total is the number of usable IPs within the subnet, which excludes the network and broadcast IPs.
+	Total uint64
+
+	// Used is the number of IPs used by the gateway and IP reservations.
+	Used uint64
+
+	// StaticFree is the number of unused IPs before the start of the DHCP range.
+	StaticFree uint64
+
+	// DHCPPool is the number of IPs within the DHCP range.
+	DHCPPool uint64
+}
+
+// NetworkUtilization describes how much of a network is in use.
+type NetworkUtilization struct {
+	Name    string
+	CIDR    string
+	Subnets []SubnetUtilization
+
+	// AvailableCabinetSubnets is the number of cabinet subnets sized by the addressing plan of the network that can still
+	// be allocated.
+	AvailableCabinetSubnets uint64
+}
+
+// ApplicationNodeForecast describes how many application nodes of a SubRole can be added before the subnets they are
+// given IP reservations in by the application network policy run out of space. Each SubRole is forecast on its own, so
+// SubRoles sharing a subnet share its capacity.
+type ApplicationNodeForecast struct {
+	SubRole string
+
+	// RemainingInStaticRange is the number of application nodes that can be added before the static range of one of
+	// the subnets needs to be expanded into the DHCP range.
+	RemainingInStaticRange uint64
+
+	// Remaining is the number of application nodes that can be added before one of the subnets runs out of IPs, even
+	// after expanding the static range into the DHCP range.
+	Remaining uint64
+}
+
+// UtilizationReport describes how much of each network is in use, and forecasts how much more hardware can be added
+// before a network needs to be resized.
+type UtilizationReport struct {
+	Networks []NetworkUtilization
+
+	// RiverCabinetsRemaining is the number of river cabinets that can be added before the HMN_RVR or NMN_RVR networks
+	// run out of space for cabinet subnets.
+	RiverCabinetsRemaining uint64
+
+	// ApplicationNodes contains the forecast for each SubRole of the application network policy that has at least one
+	// of its networks present, sorted by SubRole.
+	ApplicationNodes []ApplicationNodeForecast
+}
+
+// BuildUtilizationReport will determine the utilization of each network, and forecast how many river cabinets and
+// application nodes can be added to the system. Application nodes are forecast using the same application network
+// policy the update command allocates their IPs with, and if no policy is given the default policy is used.
+func BuildUtilizationReport(networks map[string]sls_common.NetworkExtraProperties, addressingPlan configs.AddressingPlan, applicationNetworkPolicy configs.ApplicationNetworkPolicy) (UtilizationReport, error) {
+	var report UtilizationReport
+
+	// Sort the networks to make the report deterministic
+	var networkNames []string
+	for networkName := range networks {
+		networkNames = append(networkNames, networkName)
+	}
+	sort.Strings(networkNames)
+
+	for _, networkName := range networkNames {
+		network := networks[networkName]
+		networkUtilization := NetworkUtilization{
+			Name: networkName,
+			CIDR: network.CIDR,
+		}
+
+		for _, slsSubnet := range network.Subnets {
+			subnetUtilization, err := BuildSubnetUtilization(slsSubnet)
+			if err != nil {
+				return UtilizationReport{}, fmt.Errorf("unable to determine utilization of subnet (%s) in network (%s): %w", slsSubnet.Name, networkName, err)
+			}
+
+			networkUtilization.Subnets = append(networkUtilization.Subnets, subnetUtilization)
+		}
+
+		if networkName == "HMN_RVR" || networkName == "NMN_RVR" {
+			availableSubnets, err := CountAvailableSubnets(network, addressingPlan.ForNetwork(networkName).PrefixLength)
+			if err != nil {
+				return UtilizationReport{}, fmt.Errorf("unable to determine available cabinet subnets in network (%s): %w", networkName, err)
+			}
+
+			networkUtilization.AvailableCabinetSubnets = availableSubnets
+		}
+
+		report.Networks = append(report.Networks, networkUtilization)
+	}
+
+	// A river cabinet needs a subnet in both the HMN_RVR and NMN_RVR networks
+	report.RiverCabinetsRemaining = forecast(report.Networks, []string{"HMN_RVR", "NMN_RVR"}, func(network NetworkUtilization) uint64 {
+		return network.AvailableCabinetSubnets
+	})
+
+	// An application node needs an IP in each of the subnets the application network policy lists for its SubRole
+	if applicationNetworkPolicy == nil {
+		applicationNetworkPolicy = configs.DefaultApplicationNetworkPolicy
+	}
+
+	var subRoles []string
+	for subRole := range applicationNetworkPolicy {
+		subRoles = append(subRoles, subRole)
+	}
+	sort.Strings(subRoles)
+
+	for _, subRole := range subRoles {
+		if applicationNodeForecast, ok := forecastApplicationNodes(report.Networks, subRole, applicationNetworkPolicy.ForSubRole(subRole)); ok {
+			report.ApplicationNodes = append(report.ApplicationNodes, applicationNodeForecast)
+		}
+	}
+
+	return report, nil
+}
+
+// forecastApplicationNodes will forecast how many application nodes of the SubRole can be added, which is limited by the
+// subnet with the least room. Networks that are not present are skipped, as no IPs are allocated in them. If none of
+// the networks are present, then false is returned.
+func forecastApplicationNodes(networks []NetworkUtilization, subRole string, networkSubnets []configs.ApplicationNetworkSubnet) (ApplicationNodeForecast, bool) {
+	result := ApplicationNodeForecast{SubRole: subRole}
+	found := false
+	for _, networkSubnet := range networkSubnets {
+		for _, network := range networks {
+			if network.Name != networkSubnet.Network {
+				continue
+			}
+
+			// A missing subnet has no room for application nodes
+			var subnet SubnetUtilization
+			for _, candidate := range network.Subnets {
+				if candidate.Name == networkSubnet.Subnet {
+					subnet = candidate
+				}
+			}
+
+			remainingInStaticRange, remaining := applicationNodeCapacity(subnet)
+			if !found || remainingInStaticRange < result.RemainingInStaticRange {
+				result.RemainingInStaticRange = remainingInStaticRange
+			}
+			if !found || remaining < result.Remaining {
+				result.Remaining = remaining
+			}
+			found = true
+		}
+	}
+
+	return result, found
+}
+
+// applicationNodeCapacity will determine how many IP reservations fit in the static range of the subnet, and how many
+// fit after expanding the static range into the DHCP range. The DHCP range can only shrink until its start is the
+// IP before its end, as ExpandSubnetStaticRange requires, so 2 IPs of the DHCP pool are always left for DHCP.
+func applicationNodeCapacity(subnet SubnetUtilization) (uint64, uint64) {
+	if subnet.DHCPPool == 0 {
+		// Without a DHCP range the whole subnet is available for static IPs
+		free := subnet.Total - subnet.Used
+		if subnet.Used > subnet.Total {
+			free = 0
+		}
+		return free, free
+	}
+
+	if subnet.DHCPPool < 2 {
+		return subnet.StaticFree, subnet.StaticFree
+	}
+	return subnet.StaticFree, subnet.StaticFree + subnet.DHCPPool - 2
+}
+
+// forecast will return the smallest capacity of the given networks that are present.
+func forecast(networks []NetworkUtilization, networkNames []string, capacity func(NetworkUtilization) uint64) uint64 {
+	var result uint64
+	found := false
+	for _, network := range networks {
+		for _, networkName := range networkNames {
+			if network.Name != networkName {
+				continue
+			}
+
+			if networkCapacity := capacity(network); !found || networkCapacity < result {
+				result = networkCapacity
+			}
+			found = true
+		}
+	}
+
+	return result
+}
+
+// BuildSubnetUtilization will determine how much of the subnet is in use.
+func BuildSubnetUtilization(slsSubnet sls_common.IPV4Subnet) (SubnetUtilization, error) {
+	subnet, err := netaddr.ParseIPPrefix(slsSubnet.CIDR)
+	if err != nil {
+		return SubnetUtilization{}, fmt.Errorf("failed to parse subnet CIDR (%v): %w", slsSubnet.CIDR, err)
+	}
+	subnet = subnet.Masked()
+
+	utilization := SubnetUtilization{
+		Name: slsSubnet.Name,
+		CIDR: slsSubnet.CIDR,
+	}
+
+	usableIPs := netaddr.IPRangeFrom(subnet.Range().From().Next(), subnet.Range().To().Prior())
+	if usableIPs.IsValid() {
+		utilization.Total = ipRangeSize(usableIPs)
+	}
+
+	existingIPAddresses, err := ExistingIPAddresses(slsSubnet)
+	if err != nil {
+		return SubnetUtilization{}, err
+	}
+	for _, existingRange := range existingIPAddresses.Ranges() {
+		utilization.Used += ipRangeSize(existingRange)
+	}
+
+	if slsSubnet.DHCPStart != nil && slsSubnet.DHCPEnd != nil {
+		staticFree, err := FreeIPsInStaticRange(slsSubnet)
+		if err != nil {
+			return SubnetUtilization{}, err
+		}
+		utilization.StaticFree = uint64(staticFree)
+
+		dhcpStart, ok := netaddr.FromStdIP(slsSubnet.DHCPStart)
+		if !ok {
+			return SubnetUtilization{}, fmt.Errorf("failed to convert DHCP Start IP address to netaddr struct")
+		}
+		dhcpEnd, ok := netaddr.FromStdIP(slsSubnet.DHCPEnd)
+		if !ok {
+			return SubnetUtilization{}, fmt.Errorf("failed to convert DHCP End IP address to netaddr struct")
+		}
+
+		if dhcpRange := netaddr.IPRangeFrom(dhcpStart, dhcpEnd); dhcpRange.IsValid() {
+			utilization.DHCPPool = ipRangeSize(dhcpRange)
+		}
+	}
+
+	return utilization, nil
+}
+
+// CountAvailableSubnets will count the number of subnets of the given size that can still be allocated within the
+// network.
+func CountAvailableSubnets(slsNetwork sls_common.NetworkExtraProperties, subnetMaskOneBits uint8) (uint64, error) {
+	network, err := netaddr.ParseIPPrefix(slsNetwork.CIDR)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse network CIDR (%v): %w", slsNetwork.CIDR, err)
+	}
+	network = network.Masked()
+
+	if err := validateSubnetMask(network, subnetMaskOneBits); err != nil {
+		return 0, err
+	}
+
+	var existingSubnets netaddr.IPSetBuilder
+	for _, slsSubnet := range slsNetwork.Subnets {
+		subnet, err := netaddr.ParseIPPrefix(slsSubnet.CIDR)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse subnet CIDR (%v): %w", slsSubnet.CIDR, err)
+		}
+
+		existingSubnets.AddPrefix(subnet.Masked())
+	}
+
+	existingSubnetsSet, err := existingSubnets.IPSet()
+	if err != nil {
+		return 0, err
+	}
+
+	availableSpace, err := subtractIPSet(network.Range(), existingSubnetsSet)
+	if err != nil {
+		return 0, err
+	}
+
+	// The prefixes of an IPSet are the largest aligned blocks of free space, so each prefix at least as large as the
+	// subnet holds a power of 2 number of subnets.
+	var count uint64
+	for _, prefix := range availableSpace.Prefixes() {
+		if prefix.Bits() > subnetMaskOneBits {
+			continue
+		}
+		if subnetMaskOneBits-prefix.Bits() >= 64 {
+			// Only possible with IPv6, and more than can be counted
+			return math.MaxUint64, nil
+		}
+
+		count += uint64(1) << (subnetMaskOneBits - prefix.Bits())
+	}
+
+	return count, nil
+}
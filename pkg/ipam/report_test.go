@@ -0,0 +1,210 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package ipam
+
+import (
+	"net"
+	"testing"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+)
+
+type UtilizationReportTestSuite struct {
+	suite.Suite
+}
+
+func (suite *UtilizationReportTestSuite) networks() map[string]sls_common.NetworkExtraProperties {
+	return map[string]sls_common.NetworkExtraProperties{
+		"HMN_RVR": {
+			CIDR: "10.107.0.0/20",
+			Subnets: []sls_common.IPV4Subnet{
+				{Name: "cabinet_3000", CIDR: "10.107.0.0/22", Gateway: net.ParseIP("10.107.0.1"), DHCPStart: net.ParseIP("10.107.0.10"), DHCPEnd: net.ParseIP("10.107.3.254")},
+			},
+		},
+		"NMN_RVR": {
+			CIDR: "10.106.0.0/21",
+			Subnets: []sls_common.IPV4Subnet{
+				{Name: "cabinet_3000", CIDR: "10.106.0.0/22", Gateway: net.ParseIP("10.106.0.1"), DHCPStart: net.ParseIP("10.106.0.10"), DHCPEnd: net.ParseIP("10.106.3.254")},
+			},
+		},
+		"CAN": {
+			CIDR: "10.102.10.0/24",
+			Subnets: []sls_common.IPV4Subnet{
+				{
+					Name:      "bootstrap_dhcp",
+					CIDR:      "10.102.10.0/26",
+					Gateway:   net.ParseIP("10.102.10.1"),
+					DHCPStart: net.ParseIP("10.102.10.10"),
+					DHCPEnd:   net.ParseIP("10.102.10.20"),
+					IPReservations: []sls_common.IPReservation{
+						{Name: "uan01", IPAddress: net.ParseIP("10.102.10.2")},
+						{Name: "uan02", IPAddress: net.ParseIP("10.102.10.3")},
+					},
+				},
+			},
+		},
+		"CHN": {
+			CIDR: "10.103.10.0/24",
+			Subnets: []sls_common.IPV4Subnet{
+				{
+					Name:      "bootstrap_dhcp",
+					CIDR:      "10.103.10.0/26",
+					Gateway:   net.ParseIP("10.103.10.1"),
+					DHCPStart: net.ParseIP("10.103.10.8"),
+					DHCPEnd:   net.ParseIP("10.103.10.20"),
+					IPReservations: []sls_common.IPReservation{
+						{Name: "uan01", IPAddress: net.ParseIP("10.103.10.2")},
+						{Name: "uan02", IPAddress: net.ParseIP("10.103.10.3")},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (suite *UtilizationReportTestSuite) TestBuildUtilizationReport() {
+	report, err := BuildUtilizationReport(suite.networks(), nil, nil)
+	suite.NoError(err)
+
+	suite.Len(report.Networks, 4)
+	suite.Equal(NetworkUtilization{
+		Name: "CAN",
+		CIDR: "10.102.10.0/24",
+		Subnets: []SubnetUtilization{
+			{Name: "bootstrap_dhcp", CIDR: "10.102.10.0/26", Total: 62, Used: 3, StaticFree: 6, DHCPPool: 11},
+		},
+	}, report.Networks[0])
+
+	suite.Equal(uint64(3), report.Networks[2].AvailableCabinetSubnets) // HMN_RVR
+	suite.Equal(uint64(1), report.Networks[3].AvailableCabinetSubnets) // NMN_RVR
+
+	// Limited by NMN_RVR
+	suite.Equal(uint64(1), report.RiverCabinetsRemaining)
+
+	// Limited by CHN, which has 4 free static IPs and 13 IPs in its DHCP pool. CAN has 6 free static IPs and 11 IPs in
+	// its DHCP pool, and 2 IPs of each DHCP pool are left for DHCP.
+	suite.Equal([]ApplicationNodeForecast{
+		{SubRole: "UAN", RemainingInStaticRange: 4, Remaining: 15},
+	}, report.ApplicationNodes)
+}
+
+func (suite *UtilizationReportTestSuite) TestBuildUtilizationReport_ApplicationNetworkPolicy() {
+	applicationNetworkPolicy := configs.ApplicationNetworkPolicy{
+		"Gateway": {{Network: "CAN"}},
+		"UAN":     {{Network: "CHN"}, {Network: "CAN"}},
+		"Missing": {{Network: "CMN"}},
+	}
+
+	report, err := BuildUtilizationReport(suite.networks(), nil, applicationNetworkPolicy)
+	suite.NoError(err)
+
+	// The Missing SubRole is left out as the CMN network does not exist
+	suite.Equal([]ApplicationNodeForecast{
+		{SubRole: "Gateway", RemainingInStaticRange: 6, Remaining: 15},
+		{SubRole: "UAN", RemainingInStaticRange: 4, Remaining: 15},
+	}, report.ApplicationNodes)
+}
+
+func (suite *UtilizationReportTestSuite) TestBuildUtilizationReport_MissingSubnet() {
+	applicationNetworkPolicy := configs.ApplicationNetworkPolicy{
+		"UAN": {{Network: "CAN", Subnet: "uai_macvlan"}},
+	}
+
+	report, err := BuildUtilizationReport(suite.networks(), nil, applicationNetworkPolicy)
+	suite.NoError(err)
+	suite.Equal([]ApplicationNodeForecast{{SubRole: "UAN"}}, report.ApplicationNodes)
+}
+
+func (suite *UtilizationReportTestSuite) TestApplicationNodeCapacity_DHCPPoolBoundary() {
+	// The static range can only be expanded until the DHCP range has 2 IPs left, which is verified against
+	// ExpandSubnetStaticRange for each size of the DHCP pool.
+	for dhcpPool := uint32(1); dhcpPool <= 4; dhcpPool++ {
+		slsSubnet := sls_common.IPV4Subnet{
+			Name:      "bootstrap_dhcp",
+			CIDR:      "10.102.10.0/26",
+			Gateway:   net.ParseIP("10.102.10.1"),
+			DHCPStart: net.ParseIP("10.102.10.10"),
+			DHCPEnd:   net.IPv4(10, 102, 10, byte(9+dhcpPool)),
+		}
+
+		utilization, err := BuildSubnetUtilization(slsSubnet)
+		suite.NoError(err)
+		suite.Equal(uint64(dhcpPool), utilization.DHCPPool)
+
+		remainingInStaticRange, remaining := applicationNodeCapacity(utilization)
+		suite.Equal(uint64(8), remainingInStaticRange)
+
+		expandBy := uint32(remaining - remainingInStaticRange)
+		if dhcpPool < 2 {
+			suite.Equal(uint32(0), expandBy, "DHCP pool of %d", dhcpPool)
+			continue
+		}
+		suite.Equal(dhcpPool-2, expandBy, "DHCP pool of %d", dhcpPool)
+
+		// Expanding by the forecast succeeds, but expanding by one more does not
+		expanded := slsSubnet
+		suite.NoError(ExpandSubnetStaticRange(&expanded, expandBy), "DHCP pool of %d", dhcpPool)
+		expanded = slsSubnet
+		suite.ErrorIs(ExpandSubnetStaticRange(&expanded, expandBy+1), ErrSubnetExhausted, "DHCP pool of %d", dhcpPool)
+	}
+}
+
+func (suite *UtilizationReportTestSuite) TestApplicationNodeCapacity_NoDHCPRange() {
+	remainingInStaticRange, remaining := applicationNodeCapacity(SubnetUtilization{Total: 62, Used: 3})
+	suite.Equal(uint64(59), remainingInStaticRange)
+	suite.Equal(uint64(59), remaining)
+}
+
+func (suite *UtilizationReportTestSuite) TestBuildUtilizationReport_AddressingPlan() {
+	addressingPlan := configs.AddressingPlan{
+		"HMN_RVR": {PrefixLength: 24},
+		"NMN_RVR": {PrefixLength: 24},
+	}
+
+	report, err := BuildUtilizationReport(suite.networks(), addressingPlan, nil)
+	suite.NoError(err)
+
+	suite.Equal(uint64(12), report.Networks[2].AvailableCabinetSubnets) // HMN_RVR
+	suite.Equal(uint64(4), report.Networks[3].AvailableCabinetSubnets)  // NMN_RVR
+	suite.Equal(uint64(4), report.RiverCabinetsRemaining)
+}
+
+func (suite *UtilizationReportTestSuite) TestCountAvailableSubnets_MixedSizes() {
+	slsNetwork := sls_common.NetworkExtraProperties{
+		CIDR: "10.107.0.0/22",
+		Subnets: []sls_common.IPV4Subnet{
+			{CIDR: "10.107.1.0/24"},
+			{CIDR: "10.107.2.128/25"},
+		},
+	}
+
+	count, err := CountAvailableSubnets(slsNetwork, 24)
+	suite.NoError(err)
+	suite.Equal(uint64(2), count) // 10.107.0.0/24 and 10.107.3.0/24
+}
+
+func TestUtilizationReportTestSuite(t *testing.T) {
+	suite.Run(t, new(UtilizationReportTestSuite))
+}
@@ -0,0 +1,182 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package ipam
+
+import (
+	"fmt"
+	"sort"
+
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+)
+
+// VLANUsage describes where a VLAN is in use. Subnet is empty when the VLAN comes from the VlanRange of the network.
+type VLANUsage struct {
+	Network string
+	Subnet  string
+}
+
+func (u VLANUsage) String() string {
+	if u.Subnet == "" {
+		return fmt.Sprintf("%s (VlanRange)", u.Network)
+	}
+
+	return fmt.Sprintf("%s/%s", u.Network, u.Subnet)
+}
+
+// VLANRegistry keeps track of the VLANs in use across all networks of the system. The key is the VLAN.
+type VLANRegistry map[int16][]VLANUsage
+
+// The range of valid VLANs a VlanRange is clamped to.
+const (
+	minVLAN = 1
+	maxVLAN = 4094
+)
+
+// sharedVLANNetworks maps networks that share the VLAN of another network by design to that network, such as the
+// NMNLB using the VLAN of the NMN.
+var sharedVLANNetworks = map[string]string{
+	"NMNLB": "NMN",
+	"HMNLB": "HMN",
+}
+
+// BuildVLANRegistry will build a registry of VLANs in use by the subnets, and reserved by the VlanRange of each network.
+// VLAN 0 is ignored, as it is used by networks that are not tagged. A VlanRange with 2 values is treated as a range
+// of VLANs clamped to 1-4094, otherwise each value is treated as a single VLAN.
+func BuildVLANRegistry(networks map[string]sls_common.NetworkExtraProperties) VLANRegistry {
+	registry := VLANRegistry{}
+	for networkName, network := range networks {
+		vlanRange := network.VlanRange
+		if len(vlanRange) == 2 && vlanRange[0] < vlanRange[1] {
+			// Loop with an int clamped to the valid VLANs, so a range ending at the largest int16 does not wrap around
+			low, high := int(vlanRange[0]), int(vlanRange[1])
+			if low < minVLAN {
+				low = minVLAN
+			}
+			if high > maxVLAN {
+				high = maxVLAN
+			}
+			for vlan := low; vlan <= high; vlan++ {
+				registry.Add(int16(vlan), VLANUsage{Network: networkName})
+			}
+		} else {
+			for _, vlan := range vlanRange {
+				registry.Add(vlan, VLANUsage{Network: networkName})
+			}
+		}
+
+		for _, subnet := range network.Subnets {
+			registry.Add(subnet.VlanID, VLANUsage{Network: networkName, Subnet: subnet.Name})
+		}
+	}
+
+	// Sort the usages to make the registry deterministic
+	for _, usages := range registry {
+		sort.Slice(usages, func(i, j int) bool {
+			if usages[i].Network != usages[j].Network {
+				return usages[i].Network < usages[j].Network
+			}
+			return usages[i].Subnet < usages[j].Subnet
+		})
+	}
+
+	return registry
+}
+
+// Add will record the usage of a VLAN. Usage of VLAN 0 is ignored.
+func (r VLANRegistry) Add(vlan int16, usage VLANUsage) {
+	if vlan == 0 {
+		return
+	}
+
+	r[vlan] = append(r[vlan], usage)
+}
+
+// InUseByOtherNetwork determines if the VLAN is in use by any network other than the given network.
+func (r VLANRegistry) InUseByOtherNetwork(vlan int16, networkName string) bool {
+	for _, usage := range r[vlan] {
+		if usage.Network != networkName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Networks will return the sorted names of the networks using the given VLAN.
+func (r VLANRegistry) Networks(vlan int16) []string {
+	networksSeen := map[string]bool{}
+	var networks []string
+	for _, usage := range r[vlan] {
+		if !networksSeen[usage.Network] {
+			networksSeen[usage.Network] = true
+			networks = append(networks, usage.Network)
+		}
+	}
+	sort.Strings(networks)
+
+	return networks
+}
+
+// Duplicates will find the VLANs that are in use by more than one network. Multiple subnets within the same network are
+// allowed to share a VLAN, as are the NMN and HMN with their load balancer networks NMNLB and HMNLB.
+func (r VLANRegistry) Duplicates() map[int16][]VLANUsage {
+	duplicates := map[int16][]VLANUsage{}
+	for vlan, usages := range r {
+		networks := map[string]bool{}
+		for _, network := range r.Networks(vlan) {
+			if sharedNetwork, ok := sharedVLANNetworks[network]; ok {
+				network = sharedNetwork
+			}
+			networks[network] = true
+		}
+
+		if len(networks) > 1 {
+			duplicates[vlan] = usages
+		}
+	}
+
+	return duplicates
+}
+
+// DuplicatesSummary will describe each duplicate VLAN in a human readable form, sorted by VLAN.
+func (r VLANRegistry) DuplicatesSummary() []string {
+	duplicates := r.Duplicates()
+
+	var vlans []int
+	for vlan := range duplicates {
+		vlans = append(vlans, int(vlan))
+	}
+	sort.Ints(vlans)
+
+	var summary []string
+	for _, vlan := range vlans {
+		var usages []string
+		for _, usage := range duplicates[int16(vlan)] {
+			usages = append(usages, usage.String())
+		}
+
+		summary = append(summary, fmt.Sprintf("VLAN %d is used by multiple networks: %v", vlan, usages))
+	}
+
+	return summary
+}
@@ -0,0 +1,161 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package ipam
+
+import (
+	"testing"
+
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+)
+
+type VLANRegistryTestSuite struct {
+	suite.Suite
+}
+
+func (suite *VLANRegistryTestSuite) networks() map[string]sls_common.NetworkExtraProperties {
+	return map[string]sls_common.NetworkExtraProperties{
+		"HMN": {
+			VlanRange: []int16{4},
+			Subnets: []sls_common.IPV4Subnet{
+				{Name: "network_hardware", VlanID: 4},
+				{Name: "bootstrap_dhcp", VlanID: 4},
+			},
+		},
+		"HMN_RVR": {
+			VlanRange: []int16{1513, 1515},
+			Subnets: []sls_common.IPV4Subnet{
+				{Name: "cabinet_3000", VlanID: 1513},
+			},
+		},
+		"MTL": {
+			VlanRange: []int16{0},
+			Subnets: []sls_common.IPV4Subnet{
+				{Name: "network_hardware", VlanID: 0},
+			},
+		},
+		"NMN": {
+			VlanRange: []int16{2},
+			Subnets: []sls_common.IPV4Subnet{
+				{Name: "network_hardware", VlanID: 2},
+			},
+		},
+	}
+}
+
+func (suite *VLANRegistryTestSuite) TestBuildVLANRegistry() {
+	registry := BuildVLANRegistry(suite.networks())
+
+	expectedRegistry := VLANRegistry{
+		2: {
+			{Network: "NMN"},
+			{Network: "NMN", Subnet: "network_hardware"},
+		},
+		4: {
+			{Network: "HMN"},
+			{Network: "HMN", Subnet: "bootstrap_dhcp"},
+			{Network: "HMN", Subnet: "network_hardware"},
+		},
+		1513: {
+			{Network: "HMN_RVR"},
+			{Network: "HMN_RVR", Subnet: "cabinet_3000"},
+		},
+		1514: {
+			{Network: "HMN_RVR"},
+		},
+		1515: {
+			{Network: "HMN_RVR"},
+		},
+	}
+
+	suite.Equal(expectedRegistry, registry)
+	suite.Empty(registry.Duplicates())
+}
+
+func (suite *VLANRegistryTestSuite) TestBuildVLANRegistry_MaxVLANRange() {
+	registry := BuildVLANRegistry(map[string]sls_common.NetworkExtraProperties{
+		"HMN_RVR": {VlanRange: []int16{4090, 32767}},
+	})
+
+	suite.Len(registry, 5)
+	suite.Contains(registry, int16(4094))
+	suite.NotContains(registry, int16(4095))
+}
+
+func (suite *VLANRegistryTestSuite) TestLoadBalancerNetworksShareVLAN() {
+	networks := suite.networks()
+	networks["NMNLB"] = sls_common.NetworkExtraProperties{
+		VlanRange: []int16{2},
+		Subnets:   []sls_common.IPV4Subnet{{Name: "nmn_metallb_address_pool", VlanID: 2}},
+	}
+	networks["HMNLB"] = sls_common.NetworkExtraProperties{
+		VlanRange: []int16{4},
+		Subnets:   []sls_common.IPV4Subnet{{Name: "hmn_metallb_address_pool", VlanID: 4}},
+	}
+
+	registry := BuildVLANRegistry(networks)
+	suite.Empty(registry.Duplicates())
+	suite.True(registry.InUseByOtherNetwork(2, "NMN"))
+
+	// Sharing with any other network is still reported
+	networks["CAN"] = sls_common.NetworkExtraProperties{VlanRange: []int16{2}}
+	suite.Equal([]string{
+		"VLAN 2 is used by multiple networks: [CAN (VlanRange) NMN (VlanRange) NMN/network_hardware NMNLB (VlanRange) NMNLB/nmn_metallb_address_pool]",
+	}, BuildVLANRegistry(networks).DuplicatesSummary())
+}
+
+func (suite *VLANRegistryTestSuite) TestInUseByOtherNetwork() {
+	registry := BuildVLANRegistry(suite.networks())
+
+	suite.True(registry.InUseByOtherNetwork(1514, "NMN_RVR"))
+	suite.False(registry.InUseByOtherNetwork(1514, "HMN_RVR"))
+	suite.False(registry.InUseByOtherNetwork(1600, "HMN_RVR"))
+	suite.False(registry.InUseByOtherNetwork(0, "HMN_RVR"))
+}
+
+func (suite *VLANRegistryTestSuite) TestDuplicates() {
+	networks := suite.networks()
+	networks["CAN"] = sls_common.NetworkExtraProperties{
+		VlanRange: []int16{6},
+		Subnets: []sls_common.IPV4Subnet{
+			{Name: "bootstrap_dhcp", VlanID: 6},
+			{Name: "uai_macvlan", VlanID: 1514},
+		},
+	}
+
+	registry := BuildVLANRegistry(networks)
+	suite.Equal(map[int16][]VLANUsage{
+		1514: {
+			{Network: "CAN", Subnet: "uai_macvlan"},
+			{Network: "HMN_RVR"},
+		},
+	}, registry.Duplicates())
+
+	suite.Equal([]string{
+		"VLAN 1514 is used by multiple networks: [CAN/uai_macvlan HMN_RVR (VlanRange)]",
+	}, registry.DuplicatesSummary())
+}
+
+func TestVLANRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(VLANRegistryTestSuite))
+}
@@ -24,6 +24,7 @@ package sls
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
 	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
@@ -229,3 +230,48 @@ func SwitchAliases(allHardware map[string]sls_common.GenericHardware) (map[strin
 
 	return result, nil
 }
+
+// decodeNodeExtraProperties will retrieve the extra properties of a node, which are either the runtime structure or
+// decoded from JSON.
+func decodeNodeExtraProperties(hardware sls_common.GenericHardware) (sls_common.ComptypeNode, error) {
+	if ep, ok := hardware.ExtraPropertiesRaw.(sls_common.ComptypeNode); ok {
+		// If we are there, then the extra properties where created at runtime
+		return ep, nil
+	}
+
+	// If we are there, then the extra properties came from JSON
+	var nodeEP sls_common.ComptypeNode
+	if err := mapstructure.Decode(hardware.ExtraPropertiesRaw, &nodeEP); err != nil {
+		return sls_common.ComptypeNode{}, fmt.Errorf("failed to decode extra properties for (%s): %w", hardware.Xname, err)
+	}
+
+	return nodeEP, nil
+}
+
+// NodeNIDs builds a lookup map of NIDs to the xnames of the nodes using that NID. Nodes without a NID are ignored.
+func NodeNIDs(allHardware map[string]sls_common.GenericHardware) (map[int][]string, error) {
+	result := map[int][]string{}
+
+	for _, hardware := range allHardware {
+		if xnametypes.GetHMSType(hardware.Xname) != xnametypes.Node {
+			continue
+		}
+
+		nodeEP, err := decodeNodeExtraProperties(hardware)
+		if err != nil {
+			return nil, err
+		}
+
+		if nodeEP.NID == 0 {
+			continue
+		}
+
+		result[nodeEP.NID] = append(result[nodeEP.NID], hardware.Xname)
+	}
+
+	for _, xnames := range result {
+		sort.Strings(xnames)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,91 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package sls
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"inet.af/netaddr"
+)
+
+// The SLS subnet schema does not have a field for IPv6, so the IPv6 prefix of a subnet in a dual-stack network is
+// recorded in its comment as a key=value pair.
+const subnetIPv6PrefixKey = "ipv6_prefix="
+
+// SubnetIPv6Prefix will retrieve the IPv6 prefix recorded on the subnet. If the subnet does not have an IPv6 prefix,
+// then false is returned.
+func SubnetIPv6Prefix(subnet sls_common.IPV4Subnet) (netaddr.IPPrefix, bool, error) {
+	for _, field := range strings.Fields(subnet.Comment) {
+		if !strings.HasPrefix(field, subnetIPv6PrefixKey) {
+			continue
+		}
+
+		prefix, err := netaddr.ParseIPPrefix(strings.TrimPrefix(field, subnetIPv6PrefixKey))
+		if err != nil {
+			return netaddr.IPPrefix{}, false, fmt.Errorf("unable to parse IPv6 prefix of subnet (%s): %w", subnet.Name, err)
+		}
+
+		return prefix, true, nil
+	}
+
+	return netaddr.IPPrefix{}, false, nil
+}
+
+// SetSubnetIPv6Prefix will record the IPv6 prefix on the subnet, replacing the IPv6 prefix already recorded in place or
+// appending it to the comment. The rest of the comment is left untouched.
+func SetSubnetIPv6Prefix(subnet *sls_common.IPV4Subnet, prefix netaddr.IPPrefix) {
+	field := subnetIPv6PrefixKey + prefix.String()
+
+	if start, end, ok := subnetIPv6PrefixField(subnet.Comment); ok {
+		subnet.Comment = subnet.Comment[:start] + field + subnet.Comment[end:]
+		return
+	}
+
+	if subnet.Comment != "" && !unicode.IsSpace(rune(subnet.Comment[len(subnet.Comment)-1])) {
+		subnet.Comment += " "
+	}
+	subnet.Comment += field
+}
+
+// subnetIPv6PrefixField will find the start and end of the whitespace separated IPv6 prefix field in the comment.
+func subnetIPv6PrefixField(comment string) (int, int, bool) {
+	start := -1
+	for i, r := range comment + " " {
+		if !unicode.IsSpace(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+
+		if start >= 0 && strings.HasPrefix(comment[start:i], subnetIPv6PrefixKey) {
+			return start, i, true
+		}
+		start = -1
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,79 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package sls
+
+import (
+	"testing"
+
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+	"inet.af/netaddr"
+)
+
+type SubnetIPv6PrefixTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SubnetIPv6PrefixTestSuite) TestSetAndRetrieve() {
+	subnet := sls_common.IPV4Subnet{Name: "cabinet_3000", Comment: "added by hand"}
+
+	SetSubnetIPv6Prefix(&subnet, netaddr.MustParseIPPrefix("fd00:0:0:100::/64"))
+	suite.Equal("added by hand ipv6_prefix=fd00:0:0:100::/64", subnet.Comment)
+
+	prefix, ok, err := SubnetIPv6Prefix(subnet)
+	suite.NoError(err)
+	suite.True(ok)
+	suite.Equal(netaddr.MustParseIPPrefix("fd00:0:0:100::/64"), prefix)
+}
+
+func (suite *SubnetIPv6PrefixTestSuite) TestSetReplacesExisting() {
+	subnet := sls_common.IPV4Subnet{Name: "cabinet_3000", Comment: "ipv6_prefix=fd00:0:0:100::/64  added\tby hand"}
+
+	SetSubnetIPv6Prefix(&subnet, netaddr.MustParseIPPrefix("fd00:0:0:101::/64"))
+	suite.Equal("ipv6_prefix=fd00:0:0:101::/64  added\tby hand", subnet.Comment)
+}
+
+func (suite *SubnetIPv6PrefixTestSuite) TestSetKeepsWhitespace() {
+	subnet := sls_common.IPV4Subnet{Name: "cabinet_3000", Comment: "  added  by hand\n"}
+
+	SetSubnetIPv6Prefix(&subnet, netaddr.MustParseIPPrefix("fd00:0:0:100::/64"))
+	suite.Equal("  added  by hand\nipv6_prefix=fd00:0:0:100::/64", subnet.Comment)
+
+	SetSubnetIPv6Prefix(&subnet, netaddr.MustParseIPPrefix("fd00:0:0:101::/64"))
+	suite.Equal("  added  by hand\nipv6_prefix=fd00:0:0:101::/64", subnet.Comment)
+}
+
+func (suite *SubnetIPv6PrefixTestSuite) TestMissing() {
+	_, ok, err := SubnetIPv6Prefix(sls_common.IPV4Subnet{Name: "cabinet_3000", Comment: "added by hand"})
+	suite.NoError(err)
+	suite.False(ok)
+}
+
+func (suite *SubnetIPv6PrefixTestSuite) TestInvalid() {
+	_, _, err := SubnetIPv6Prefix(sls_common.IPV4Subnet{Name: "cabinet_3000", Comment: "ipv6_prefix=bogus"})
+	suite.Error(err)
+}
+
+func TestSubnetIPv6PrefixTestSuite(t *testing.T) {
+	suite.Run(t, new(SubnetIPv6PrefixTestSuite))
+}
@@ -23,8 +23,12 @@
 package sls
 
 import (
+	"fmt"
+	"sort"
+
 	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
 	"github.com/mitchellh/mapstructure"
+	"inet.af/netaddr"
 )
 
 func DecodeNetworkExtraProperties(extraPropertiesRaw interface{}, extraProperties *sls_common.NetworkExtraProperties) error {
@@ -40,6 +44,21 @@ func DecodeNetworkExtraProperties(extraPropertiesRaw interface{}, extraPropertie
 	return decoder.Decode(extraPropertiesRaw)
 }
 
+// DecodeAllNetworkExtraProperties will decode the extra properties of every network. The key is the network name.
+func DecodeAllNetworkExtraProperties(networks map[string]sls_common.Network) (map[string]sls_common.NetworkExtraProperties, error) {
+	allExtraProperties := map[string]sls_common.NetworkExtraProperties{}
+	for networkName, slsNetwork := range networks {
+		var ep sls_common.NetworkExtraProperties
+		if err := DecodeNetworkExtraProperties(slsNetwork.ExtraPropertiesRaw, &ep); err != nil {
+			return nil, fmt.Errorf("failed to decode extra properties for network (%s): %w", networkName, err)
+		}
+
+		allExtraProperties[networkName] = ep
+	}
+
+	return allExtraProperties, nil
+}
+
 func Networks(state sls_common.SLSState) (networks sls_common.NetworkArray) {
 	for _, network := range state.Networks {
 		networks = append(networks, network)
@@ -47,3 +66,105 @@ func Networks(state sls_common.SLSState) (networks sls_common.NetworkArray) {
 
 	return networks
 }
+
+// NetworkVlanRange will compute the VlanRange of a network so it covers both the existing VlanRange and the VLANs of
+// all of its subnets. An existing VlanRange of 2 ascending VLANs is a range, and is widened to the lowest and highest
+// VLAN. Any other VlanRange is a list of VLANs, and the missing VLANs are appended to it in order. A list that ends up
+// with 2 VLANs that are not adjacent is ordered highest first, so it is not mistaken for a range. Untagged subnets with
+// VLAN 0 are ignored.
+func NetworkVlanRange(extraProperties sls_common.NetworkExtraProperties) []int16 {
+	var subnetVLANs []int16
+	for _, subnet := range extraProperties.Subnets {
+		if subnet.VlanID == 0 {
+			continue
+		}
+		subnetVLANs = append(subnetVLANs, subnet.VlanID)
+	}
+	sort.Slice(subnetVLANs, func(i, j int) bool { return subnetVLANs[i] < subnetVLANs[j] })
+
+	vlanRange := extraProperties.VlanRange
+	if len(vlanRange) == 2 && vlanRange[0] < vlanRange[1] {
+		low, high := vlanRange[0], vlanRange[1]
+		for _, vlan := range subnetVLANs {
+			if vlan < low {
+				low = vlan
+			}
+			if vlan > high {
+				high = vlan
+			}
+		}
+
+		return []int16{low, high}
+	}
+
+	vlans := append([]int16{}, vlanRange...)
+	present := map[int16]bool{}
+	for _, vlan := range vlans {
+		present[vlan] = true
+	}
+	for _, vlan := range subnetVLANs {
+		if !present[vlan] {
+			present[vlan] = true
+			vlans = append(vlans, vlan)
+		}
+	}
+
+	if len(vlans) == 0 {
+		return extraProperties.VlanRange
+	}
+	if len(vlans) == 2 && vlans[0] < vlans[1]-1 {
+		vlans[0], vlans[1] = vlans[1], vlans[0]
+	}
+
+	return vlans
+}
+
+// NetworkIPRanges will compute the IPRanges of a network so it covers all of its subnets. If the existing IP ranges
+// already cover the subnets, then they are returned unchanged. Otherwise the smallest set of CIDRs covering both the
+// existing IP ranges and the subnets is returned.
+func NetworkIPRanges(ipRanges []string, extraProperties sls_common.NetworkExtraProperties) ([]string, error) {
+	var existingBuilder netaddr.IPSetBuilder
+	for _, ipRange := range ipRanges {
+		prefix, err := netaddr.ParseIPPrefix(ipRange)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse network IP range (%s): %w", ipRange, err)
+		}
+		existingBuilder.AddPrefix(prefix.Masked())
+	}
+
+	existingSet, err := existingBuilder.IPSet()
+	if err != nil {
+		return nil, err
+	}
+
+	var subnetsBuilder netaddr.IPSetBuilder
+	subnetsBuilder.AddSet(existingSet)
+	covered := true
+	for _, subnet := range extraProperties.Subnets {
+		prefix, err := netaddr.ParseIPPrefix(subnet.CIDR)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse subnet CIDR (%s): %w", subnet.CIDR, err)
+		}
+
+		if !existingSet.ContainsPrefix(prefix.Masked()) {
+			covered = false
+		}
+		subnetsBuilder.AddPrefix(prefix.Masked())
+	}
+
+	if covered {
+		return ipRanges, nil
+	}
+
+	allSet, err := subnetsBuilder.IPSet()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, prefix := range allSet.Prefixes() {
+		result = append(result, prefix.String())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,230 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package sls
+
+import (
+	"fmt"
+	"sort"
+
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"inet.af/netaddr"
+)
+
+// LintSeverity describes how severe a network lint finding is.
+type LintSeverity string
+
+const (
+	// LintSeverityError is used for problems that will cause IP allocation to fail, or produce conflicting IPs.
+	LintSeverityError LintSeverity = "error"
+
+	// LintSeverityWarning is used for problems that may be intentional, but should be looked at.
+	LintSeverityWarning LintSeverity = "warning"
+)
+
+// LintFinding is a problem found with a SLS network.
+type LintFinding struct {
+	Severity LintSeverity
+	Network  string
+	Subnet   string
+	Message  string
+}
+
+func (f LintFinding) String() string {
+	location := f.Network
+	if f.Subnet != "" {
+		location = fmt.Sprintf("%s/%s", f.Network, f.Subnet)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", f.Severity, location, f.Message)
+}
+
+// LintFindings is the list of problems found with the SLS networks.
+type LintFindings []LintFinding
+
+// HasErrors determines if any of the findings are errors.
+func (findings LintFindings) HasErrors() bool {
+	for _, finding := range findings {
+		if finding.Severity == LintSeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LintNetworks will check the SLS networks for problems, such as IP reservations outside of their subnet, subnets
+// outside of their network, overlapping subnets, duplicate IP reservations, gateways within DHCP ranges, and DHCP
+// ranges that end before they start. The subnet layout CSI creates is not reported, which is subnets with the mask of
+// the network overlapping the other subnets, and subnets using the gateway of the network. Findings are sorted by
+// network and subnet.
+func LintNetworks(networks map[string]sls_common.Network) LintFindings {
+	var networkNames []string
+	for networkName := range networks {
+		networkNames = append(networkNames, networkName)
+	}
+	sort.Strings(networkNames)
+
+	var findings LintFindings
+	for _, networkName := range networkNames {
+		var extraProperties sls_common.NetworkExtraProperties
+		if err := DecodeNetworkExtraProperties(networks[networkName].ExtraPropertiesRaw, &extraProperties); err != nil {
+			findings = append(findings, LintFinding{
+				Severity: LintSeverityError,
+				Network:  networkName,
+				Message:  fmt.Sprintf("unable to decode extra properties: %v", err),
+			})
+			continue
+		}
+
+		findings = append(findings, lintNetwork(networkName, extraProperties)...)
+	}
+
+	return findings
+}
+
+func lintNetwork(networkName string, extraProperties sls_common.NetworkExtraProperties) LintFindings {
+	var findings LintFindings
+	addFinding := func(severity LintSeverity, subnetName string, format string, a ...interface{}) {
+		findings = append(findings, LintFinding{
+			Severity: severity,
+			Network:  networkName,
+			Subnet:   subnetName,
+			Message:  fmt.Sprintf(format, a...),
+		})
+	}
+
+	network, err := netaddr.ParseIPPrefix(extraProperties.CIDR)
+	if err != nil {
+		addFinding(LintSeverityError, "", "unable to parse network CIDR (%s)", extraProperties.CIDR)
+		return findings
+	}
+
+	type parsedSubnet struct {
+		name   string
+		prefix netaddr.IPPrefix
+	}
+	var subnets []parsedSubnet
+
+	// Keep track of which reservation is using an IP across all subnets of the network
+	ipsInUse := map[netaddr.IP]string{}
+
+	for _, slsSubnet := range extraProperties.Subnets {
+		subnet, err := netaddr.ParseIPPrefix(slsSubnet.CIDR)
+		if err != nil {
+			addFinding(LintSeverityError, slsSubnet.Name, "unable to parse subnet CIDR (%s)", slsSubnet.CIDR)
+			continue
+		}
+
+		if !network.Contains(subnet.IP()) || subnet.Bits() < network.Bits() {
+			addFinding(LintSeverityError, slsSubnet.Name, "subnet CIDR (%s) is outside of the network CIDR (%s)", slsSubnet.CIDR, extraProperties.CIDR)
+		}
+
+		for _, other := range subnets {
+			// CSI gives the bootstrap_dhcp and network_hardware subnets the mask of the network (the supernet hack),
+			// so they overlap every other subnet of the network by design.
+			if spansNetwork(network, other.prefix) || spansNetwork(network, subnet) {
+				continue
+			}
+
+			if other.prefix.Overlaps(subnet) {
+				addFinding(LintSeverityError, slsSubnet.Name, "subnet CIDR (%s) overlaps with subnet (%s) with CIDR (%s)", slsSubnet.CIDR, other.name, other.prefix)
+			}
+		}
+		subnets = append(subnets, parsedSubnet{name: slsSubnet.Name, prefix: subnet})
+
+		// Gateway. CSI uses the gateway of the network for the supernet hacked subnets and the uai_macvlan subnet, so
+		// it is allowed to be outside of the subnet CIDR.
+		gateway, gatewayOK := netaddr.FromStdIP(slsSubnet.Gateway)
+		if !gatewayOK {
+			addFinding(LintSeverityError, slsSubnet.Name, "unable to parse gateway (%v)", slsSubnet.Gateway)
+		} else if !subnet.Contains(gateway) && gateway != supernetGateway(network) {
+			addFinding(LintSeverityError, slsSubnet.Name, "gateway (%s) is outside of the subnet CIDR (%s)", gateway, slsSubnet.CIDR)
+		}
+
+		// DHCP range
+		var dhcpRange netaddr.IPRange
+		if (slsSubnet.DHCPStart == nil) != (slsSubnet.DHCPEnd == nil) {
+			addFinding(LintSeverityWarning, slsSubnet.Name, "only one of DHCPStart (%v) and DHCPEnd (%v) is set", slsSubnet.DHCPStart, slsSubnet.DHCPEnd)
+		} else if slsSubnet.DHCPStart != nil {
+			dhcpStart, startOK := netaddr.FromStdIP(slsSubnet.DHCPStart)
+			dhcpEnd, endOK := netaddr.FromStdIP(slsSubnet.DHCPEnd)
+			switch {
+			case !startOK || !endOK:
+				addFinding(LintSeverityError, slsSubnet.Name, "unable to parse DHCP range (%v) to (%v)", slsSubnet.DHCPStart, slsSubnet.DHCPEnd)
+			case dhcpEnd.Less(dhcpStart):
+				addFinding(LintSeverityError, slsSubnet.Name, "DHCPStart (%s) is after DHCPEnd (%s)", dhcpStart, dhcpEnd)
+			case !subnet.Contains(dhcpStart) || !subnet.Contains(dhcpEnd):
+				addFinding(LintSeverityError, slsSubnet.Name, "DHCP range (%s to %s) is outside of the subnet CIDR (%s)", dhcpStart, dhcpEnd, slsSubnet.CIDR)
+			default:
+				dhcpRange = netaddr.IPRangeFrom(dhcpStart, dhcpEnd)
+				if gatewayOK && dhcpRange.Contains(gateway) {
+					addFinding(LintSeverityError, slsSubnet.Name, "gateway (%s) is within the DHCP range (%s to %s)", gateway, dhcpStart, dhcpEnd)
+				}
+			}
+		}
+
+		// IP reservations
+		namesInUse := map[string]bool{}
+		for _, ipReservation := range slsSubnet.IPReservations {
+			if namesInUse[ipReservation.Name] {
+				addFinding(LintSeverityError, slsSubnet.Name, "duplicate IP reservation name (%s)", ipReservation.Name)
+			}
+			namesInUse[ipReservation.Name] = true
+
+			ip, ok := netaddr.FromStdIP(ipReservation.IPAddress)
+			if !ok {
+				addFinding(LintSeverityError, slsSubnet.Name, "unable to parse IP (%v) of IP reservation (%s)", ipReservation.IPAddress, ipReservation.Name)
+				continue
+			}
+
+			if !subnet.Contains(ip) {
+				addFinding(LintSeverityError, slsSubnet.Name, "IP reservation (%s) with IP (%s) is outside of the subnet CIDR (%s)", ipReservation.Name, ip, slsSubnet.CIDR)
+			}
+			if gatewayOK && ip == gateway {
+				addFinding(LintSeverityError, slsSubnet.Name, "IP reservation (%s) uses the gateway IP (%s)", ipReservation.Name, ip)
+			}
+			if dhcpRange.IsValid() && dhcpRange.Contains(ip) {
+				addFinding(LintSeverityWarning, slsSubnet.Name, "IP reservation (%s) with IP (%s) is within the DHCP range (%s)", ipReservation.Name, ip, dhcpRange)
+			}
+
+			reservation := fmt.Sprintf("%s/%s", slsSubnet.Name, ipReservation.Name)
+			if otherReservation, present := ipsInUse[ip]; present {
+				addFinding(LintSeverityError, slsSubnet.Name, "IP reservation (%s) has the same IP (%s) as IP reservation (%s)", ipReservation.Name, ip, otherReservation)
+			} else {
+				ipsInUse[ip] = reservation
+			}
+		}
+	}
+
+	return findings
+}
+
+// spansNetwork determines if the subnet covers the whole network, as subnets do after the CSI supernet hack.
+func spansNetwork(network, subnet netaddr.IPPrefix) bool {
+	return subnet.Bits() == network.Bits() && network.Contains(subnet.IP())
+}
+
+// supernetGateway is the gateway CSI uses for the network, which is the first IP of the network CIDR.
+func supernetGateway(network netaddr.IPPrefix) netaddr.IP {
+	return network.Masked().IP().Next()
+}
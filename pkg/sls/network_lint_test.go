@@ -0,0 +1,250 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package sls
+
+import (
+	"net"
+	"testing"
+
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+)
+
+type NetworkLintTestSuite struct {
+	suite.Suite
+}
+
+func (suite *NetworkLintTestSuite) buildNetworks(extraProperties sls_common.NetworkExtraProperties) map[string]sls_common.Network {
+	return map[string]sls_common.Network{
+		"HMN": {
+			Name:               "HMN",
+			ExtraPropertiesRaw: extraProperties,
+		},
+	}
+}
+
+func (suite *NetworkLintTestSuite) validSubnet() sls_common.IPV4Subnet {
+	return sls_common.IPV4Subnet{
+		Name:      "bootstrap_dhcp",
+		CIDR:      "10.254.1.0/24",
+		Gateway:   net.ParseIP("10.254.1.1"),
+		DHCPStart: net.ParseIP("10.254.1.50"),
+		DHCPEnd:   net.ParseIP("10.254.1.254"),
+		IPReservations: []sls_common.IPReservation{
+			{Name: "ncn-m001", IPAddress: net.ParseIP("10.254.1.4")},
+			{Name: "ncn-m002", IPAddress: net.ParseIP("10.254.1.5")},
+		},
+	}
+}
+
+func (suite *NetworkLintTestSuite) TestNoProblems() {
+	findings := LintNetworks(suite.buildNetworks(sls_common.NetworkExtraProperties{
+		CIDR:    "10.254.0.0/17",
+		Subnets: []sls_common.IPV4Subnet{suite.validSubnet()},
+	}))
+
+	suite.Empty(findings)
+	suite.False(findings.HasErrors())
+}
+
+func (suite *NetworkLintTestSuite) TestSubnetProblems() {
+	overlappingSubnet := suite.validSubnet()
+	overlappingSubnet.Name = "network_hardware"
+	overlappingSubnet.CIDR = "10.254.1.128/25"
+	overlappingSubnet.Gateway = net.ParseIP("10.254.1.129")
+	overlappingSubnet.DHCPStart = nil
+	overlappingSubnet.DHCPEnd = nil
+	overlappingSubnet.IPReservations = nil
+
+	outsideSubnet := suite.validSubnet()
+	outsideSubnet.Name = "outside"
+	outsideSubnet.CIDR = "10.252.1.0/24"
+	outsideSubnet.Gateway = net.ParseIP("10.252.1.1")
+	outsideSubnet.DHCPStart = net.ParseIP("10.252.1.50")
+	outsideSubnet.DHCPEnd = net.ParseIP("10.252.1.254")
+	outsideSubnet.IPReservations = nil
+
+	findings := LintNetworks(suite.buildNetworks(sls_common.NetworkExtraProperties{
+		CIDR:    "10.254.0.0/17",
+		Subnets: []sls_common.IPV4Subnet{suite.validSubnet(), overlappingSubnet, outsideSubnet},
+	}))
+
+	suite.Equal(LintFindings{
+		{Severity: LintSeverityError, Network: "HMN", Subnet: "network_hardware", Message: "subnet CIDR (10.254.1.128/25) overlaps with subnet (bootstrap_dhcp) with CIDR (10.254.1.0/24)"},
+		{Severity: LintSeverityError, Network: "HMN", Subnet: "outside", Message: "subnet CIDR (10.252.1.0/24) is outside of the network CIDR (10.254.0.0/17)"},
+	}, findings)
+	suite.True(findings.HasErrors())
+}
+
+func (suite *NetworkLintTestSuite) TestReservationProblems() {
+	subnet := suite.validSubnet()
+	subnet.IPReservations = append(subnet.IPReservations,
+		sls_common.IPReservation{Name: "ncn-m002", IPAddress: net.ParseIP("10.254.1.6")},
+		sls_common.IPReservation{Name: "ncn-m003", IPAddress: net.ParseIP("10.254.1.4")},
+		sls_common.IPReservation{Name: "ncn-w001", IPAddress: net.ParseIP("10.254.2.4")},
+		sls_common.IPReservation{Name: "ncn-w002", IPAddress: net.ParseIP("10.254.1.1")},
+		sls_common.IPReservation{Name: "ncn-w003", IPAddress: net.ParseIP("10.254.1.60")},
+	)
+
+	findings := LintNetworks(suite.buildNetworks(sls_common.NetworkExtraProperties{
+		CIDR:    "10.254.0.0/17",
+		Subnets: []sls_common.IPV4Subnet{subnet},
+	}))
+
+	suite.Equal(LintFindings{
+		{Severity: LintSeverityError, Network: "HMN", Subnet: "bootstrap_dhcp", Message: "duplicate IP reservation name (ncn-m002)"},
+		{Severity: LintSeverityError, Network: "HMN", Subnet: "bootstrap_dhcp", Message: "IP reservation (ncn-m003) has the same IP (10.254.1.4) as IP reservation (bootstrap_dhcp/ncn-m001)"},
+		{Severity: LintSeverityError, Network: "HMN", Subnet: "bootstrap_dhcp", Message: "IP reservation (ncn-w001) with IP (10.254.2.4) is outside of the subnet CIDR (10.254.1.0/24)"},
+		{Severity: LintSeverityError, Network: "HMN", Subnet: "bootstrap_dhcp", Message: "IP reservation (ncn-w002) uses the gateway IP (10.254.1.1)"},
+		{Severity: LintSeverityWarning, Network: "HMN", Subnet: "bootstrap_dhcp", Message: "IP reservation (ncn-w003) with IP (10.254.1.60) is within the DHCP range (10.254.1.50-10.254.1.254)"},
+	}, findings)
+}
+
+func (suite *NetworkLintTestSuite) TestDHCPProblems() {
+	backwardsDHCP := suite.validSubnet()
+	backwardsDHCP.DHCPStart = net.ParseIP("10.254.1.254")
+	backwardsDHCP.DHCPEnd = net.ParseIP("10.254.1.50")
+	backwardsDHCP.IPReservations = nil
+
+	gatewayInDHCP := suite.validSubnet()
+	gatewayInDHCP.Name = "gateway_in_dhcp"
+	gatewayInDHCP.CIDR = "10.254.2.0/24"
+	gatewayInDHCP.Gateway = net.ParseIP("10.254.2.100")
+	gatewayInDHCP.DHCPStart = net.ParseIP("10.254.2.50")
+	gatewayInDHCP.DHCPEnd = net.ParseIP("10.254.2.254")
+	gatewayInDHCP.IPReservations = nil
+
+	partialDHCP := suite.validSubnet()
+	partialDHCP.Name = "partial_dhcp"
+	partialDHCP.CIDR = "10.254.3.0/24"
+	partialDHCP.Gateway = net.ParseIP("10.254.3.1")
+	partialDHCP.DHCPStart = net.ParseIP("10.254.3.50")
+	partialDHCP.DHCPEnd = nil
+	partialDHCP.IPReservations = nil
+
+	findings := LintNetworks(suite.buildNetworks(sls_common.NetworkExtraProperties{
+		CIDR:    "10.254.0.0/17",
+		Subnets: []sls_common.IPV4Subnet{backwardsDHCP, gatewayInDHCP, partialDHCP},
+	}))
+
+	suite.Equal(LintFindings{
+		{Severity: LintSeverityError, Network: "HMN", Subnet: "bootstrap_dhcp", Message: "DHCPStart (10.254.1.254) is after DHCPEnd (10.254.1.50)"},
+		{Severity: LintSeverityError, Network: "HMN", Subnet: "gateway_in_dhcp", Message: "gateway (10.254.2.100) is within the DHCP range (10.254.2.50 to 10.254.2.254)"},
+		{Severity: LintSeverityWarning, Network: "HMN", Subnet: "partial_dhcp", Message: "only one of DHCPStart (10.254.3.50) and DHCPEnd (<nil>) is set"},
+	}, findings)
+}
+
+func (suite *NetworkLintTestSuite) TestCSISupernetHack() {
+	// The subnets of the NMN and HMN as created by CSI with the supernet hack applied
+	networks := map[string]sls_common.Network{
+		"NMN": {Name: "NMN", ExtraPropertiesRaw: sls_common.NetworkExtraProperties{
+			CIDR: "10.252.0.0/17",
+			Subnets: []sls_common.IPV4Subnet{
+				{
+					Name:      "bootstrap_dhcp",
+					CIDR:      "10.252.0.0/17",
+					Gateway:   net.ParseIP("10.252.0.1"),
+					DHCPStart: net.ParseIP("10.252.1.10"),
+					DHCPEnd:   net.ParseIP("10.252.1.210"),
+					IPReservations: []sls_common.IPReservation{
+						{Name: "ncn-m001", IPAddress: net.ParseIP("10.252.1.4")},
+						{Name: "ncn-w001", IPAddress: net.ParseIP("10.252.1.7")},
+					},
+				},
+				{
+					Name:    "network_hardware",
+					CIDR:    "10.252.0.0/17",
+					Gateway: net.ParseIP("10.252.0.1"),
+					IPReservations: []sls_common.IPReservation{
+						{Name: "sw-spine-001", IPAddress: net.ParseIP("10.252.0.2")},
+						{Name: "sw-leaf-bmc-001", IPAddress: net.ParseIP("10.252.0.4")},
+					},
+				},
+				{
+					Name:    "uai_macvlan",
+					CIDR:    "10.252.2.0/23",
+					Gateway: net.ParseIP("10.252.0.1"),
+					IPReservations: []sls_common.IPReservation{
+						{Name: "slurmctld_service", IPAddress: net.ParseIP("10.252.2.2")},
+					},
+				},
+			},
+		}},
+		"HMN": {Name: "HMN", ExtraPropertiesRaw: sls_common.NetworkExtraProperties{
+			CIDR: "10.254.0.0/17",
+			Subnets: []sls_common.IPV4Subnet{
+				{
+					Name:      "bootstrap_dhcp",
+					CIDR:      "10.254.0.0/17",
+					Gateway:   net.ParseIP("10.254.0.1"),
+					DHCPStart: net.ParseIP("10.254.1.10"),
+					DHCPEnd:   net.ParseIP("10.254.1.210"),
+					IPReservations: []sls_common.IPReservation{
+						{Name: "ncn-m001", IPAddress: net.ParseIP("10.254.1.4")},
+					},
+				},
+				{
+					Name:    "network_hardware",
+					CIDR:    "10.254.0.0/17",
+					Gateway: net.ParseIP("10.254.0.1"),
+					IPReservations: []sls_common.IPReservation{
+						{Name: "sw-spine-001", IPAddress: net.ParseIP("10.254.0.2")},
+					},
+				},
+			},
+		}},
+	}
+
+	findings := LintNetworks(networks)
+	suite.Empty(findings)
+	suite.False(findings.HasErrors())
+}
+
+func (suite *NetworkLintTestSuite) TestGatewayOutsideSubnet() {
+	subnet := suite.validSubnet()
+	subnet.Gateway = net.ParseIP("10.254.5.1")
+
+	findings := LintNetworks(suite.buildNetworks(sls_common.NetworkExtraProperties{
+		CIDR:    "10.254.0.0/17",
+		Subnets: []sls_common.IPV4Subnet{subnet},
+	}))
+
+	suite.Equal(LintFindings{
+		{Severity: LintSeverityError, Network: "HMN", Subnet: "bootstrap_dhcp", Message: "gateway (10.254.5.1) is outside of the subnet CIDR (10.254.1.0/24)"},
+	}, findings)
+}
+
+func (suite *NetworkLintTestSuite) TestInvalidNetworkCIDR() {
+	findings := LintNetworks(suite.buildNetworks(sls_common.NetworkExtraProperties{
+		CIDR: "foo",
+	}))
+
+	suite.Equal(LintFindings{
+		{Severity: LintSeverityError, Network: "HMN", Message: "unable to parse network CIDR (foo)"},
+	}, findings)
+	suite.Equal("error: HMN: unable to parse network CIDR (foo)", findings[0].String())
+}
+
+func TestNetworkLintTestSuite(t *testing.T) {
+	suite.Run(t, new(NetworkLintTestSuite))
+}
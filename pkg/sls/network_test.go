@@ -0,0 +1,156 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package sls
+
+import (
+	"testing"
+
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+)
+
+type NetworkTestSuite struct {
+	suite.Suite
+}
+
+func (suite *NetworkTestSuite) TestNetworkVlanRange_Unchanged() {
+	extraProperties := sls_common.NetworkExtraProperties{
+		VlanRange: []int16{1513, 1769},
+		Subnets: []sls_common.IPV4Subnet{
+			{Name: "cabinet_3000", VlanID: 1513},
+			{Name: "cabinet_3001", VlanID: 1514},
+		},
+	}
+
+	suite.Equal([]int16{1513, 1769}, NetworkVlanRange(extraProperties))
+}
+
+func (suite *NetworkTestSuite) TestNetworkVlanRange_Expanded() {
+	extraProperties := sls_common.NetworkExtraProperties{
+		VlanRange: []int16{1513, 1514},
+		Subnets: []sls_common.IPV4Subnet{
+			{Name: "cabinet_3000", VlanID: 1513},
+			{Name: "cabinet_3001", VlanID: 1600},
+		},
+	}
+
+	suite.Equal([]int16{1513, 1600}, NetworkVlanRange(extraProperties))
+}
+
+func (suite *NetworkTestSuite) TestNetworkVlanRange_SingleVLAN() {
+	extraProperties := sls_common.NetworkExtraProperties{
+		VlanRange: []int16{4},
+		Subnets: []sls_common.IPV4Subnet{
+			{Name: "network_hardware", VlanID: 4},
+			{Name: "bootstrap_dhcp", VlanID: 4},
+		},
+	}
+
+	suite.Equal([]int16{4}, NetworkVlanRange(extraProperties))
+}
+
+func (suite *NetworkTestSuite) TestNetworkVlanRange_List() {
+	extraProperties := sls_common.NetworkExtraProperties{
+		VlanRange: []int16{7, 2, 4},
+		Subnets: []sls_common.IPV4Subnet{
+			{Name: "bootstrap_dhcp", VlanID: 4},
+			{Name: "cabinet_3000", VlanID: 10},
+		},
+	}
+
+	// The VLANs between the listed VLANs are not claimed
+	suite.Equal([]int16{7, 2, 4, 10}, NetworkVlanRange(extraProperties))
+}
+
+func (suite *NetworkTestSuite) TestNetworkVlanRange_SingleVLANNotWidened() {
+	extraProperties := sls_common.NetworkExtraProperties{
+		VlanRange: []int16{4},
+		Subnets: []sls_common.IPV4Subnet{
+			{Name: "bootstrap_dhcp", VlanID: 4},
+			{Name: "cabinet_3000", VlanID: 1600},
+		},
+	}
+
+	// Ordered highest first, so the VLANs from 5 to 1599 are not treated as a range
+	suite.Equal([]int16{1600, 4}, NetworkVlanRange(extraProperties))
+}
+
+func (suite *NetworkTestSuite) TestNetworkVlanRange_UntaggedSubnet() {
+	extraProperties := sls_common.NetworkExtraProperties{
+		VlanRange: []int16{1513},
+		Subnets: []sls_common.IPV4Subnet{
+			{Name: "network_hardware", VlanID: 0},
+			{Name: "cabinet_3000", VlanID: 1513},
+			{Name: "cabinet_3001", VlanID: 1514},
+		},
+	}
+
+	suite.Equal([]int16{1513, 1514}, NetworkVlanRange(extraProperties))
+}
+
+func (suite *NetworkTestSuite) TestNetworkVlanRange_OnlyUntaggedSubnets() {
+	extraProperties := sls_common.NetworkExtraProperties{
+		Subnets: []sls_common.IPV4Subnet{{Name: "bootstrap_dhcp", VlanID: 0}},
+	}
+
+	suite.Nil(NetworkVlanRange(extraProperties))
+}
+
+func (suite *NetworkTestSuite) TestNetworkVlanRange_NoVLANs() {
+	suite.Nil(NetworkVlanRange(sls_common.NetworkExtraProperties{}))
+}
+
+func (suite *NetworkTestSuite) TestNetworkIPRanges_Unchanged() {
+	extraProperties := sls_common.NetworkExtraProperties{
+		Subnets: []sls_common.IPV4Subnet{
+			{Name: "cabinet_3000", CIDR: "10.107.0.0/22"},
+			{Name: "cabinet_3001", CIDR: "10.107.4.0/22"},
+		},
+	}
+
+	ipRanges, err := NetworkIPRanges([]string{"10.107.0.0/17"}, extraProperties)
+	suite.NoError(err)
+	suite.Equal([]string{"10.107.0.0/17"}, ipRanges)
+}
+
+func (suite *NetworkTestSuite) TestNetworkIPRanges_Expanded() {
+	extraProperties := sls_common.NetworkExtraProperties{
+		Subnets: []sls_common.IPV4Subnet{
+			{Name: "cabinet_3000", CIDR: "10.107.0.0/22"},
+			{Name: "cabinet_3001", CIDR: "10.107.4.0/22"},
+		},
+	}
+
+	ipRanges, err := NetworkIPRanges([]string{"10.107.0.0/22"}, extraProperties)
+	suite.NoError(err)
+	suite.Equal([]string{"10.107.0.0/21"}, ipRanges)
+}
+
+func (suite *NetworkTestSuite) TestNetworkIPRanges_InvalidCIDR() {
+	_, err := NetworkIPRanges([]string{"foo"}, sls_common.NetworkExtraProperties{})
+	suite.EqualError(err, `failed to parse network IP range (foo): netaddr.ParseIPPrefix("foo"): no '/'`)
+}
+
+func TestNetworkTestSuite(t *testing.T) {
+	suite.Run(t, new(NetworkTestSuite))
+}
@@ -0,0 +1,208 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package sls
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/configs"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/Cray-HPE/hms-xname/xnametypes"
+)
+
+// NIDRange is an inclusive range of NIDs.
+type NIDRange struct {
+	Start int
+	End   int
+}
+
+// NIDAliasMismatch describes a compute node whose aliases do not match its NID.
+type NIDAliasMismatch struct {
+	Xname         string
+	NID           int
+	Aliases       []string
+	ExpectedAlias string
+}
+
+// NIDAuditReport contains the findings of a NID audit.
+type NIDAuditReport struct {
+	// The key is the NID, and the value is the xnames of the nodes sharing it.
+	DuplicateNIDs map[int][]string
+
+	// The key is the compute node alias, and the value is the xnames of the nodes sharing it.
+	DuplicateAliases map[string][]string
+
+	AliasMismatches []NIDAliasMismatch
+
+	// Gaps between the lowest and highest compute node NIDs. These are informational only.
+	Gaps []NIDRange
+}
+
+// HasProblems returns true if the audit found duplicate NIDs, duplicate aliases, or alias mismatches.
+func (report NIDAuditReport) HasProblems() bool {
+	return len(report.DuplicateNIDs) != 0 || len(report.DuplicateAliases) != 0 || len(report.AliasMismatches) != 0
+}
+
+// Summary builds a human readable description of the audit findings.
+func (report NIDAuditReport) Summary() []string {
+	var lines []string
+
+	var nids []int
+	for nid := range report.DuplicateNIDs {
+		nids = append(nids, nid)
+	}
+	sort.Ints(nids)
+	for _, nid := range nids {
+		lines = append(lines, fmt.Sprintf("Duplicate NID %d used by %s", nid, strings.Join(report.DuplicateNIDs[nid], ",")))
+	}
+
+	var aliases []string
+	for alias := range report.DuplicateAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		lines = append(lines, fmt.Sprintf("Duplicate alias %s used by %s", alias, strings.Join(report.DuplicateAliases[alias], ",")))
+	}
+
+	for _, mismatch := range report.AliasMismatches {
+		if mismatch.NID == 0 {
+			lines = append(lines, fmt.Sprintf("Compute node %s with aliases [%s] has no NID", mismatch.Xname, strings.Join(mismatch.Aliases, ",")))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("Node %s with NID %d has aliases [%s] expected alias %s", mismatch.Xname, mismatch.NID, strings.Join(mismatch.Aliases, ","), mismatch.ExpectedAlias))
+	}
+
+	for _, gap := range report.Gaps {
+		if gap.Start == gap.End {
+			lines = append(lines, fmt.Sprintf("Unused compute NID %d", gap.Start))
+		} else {
+			lines = append(lines, fmt.Sprintf("Unused compute NIDs %d-%d", gap.Start, gap.End))
+		}
+	}
+
+	return lines
+}
+
+// AuditNIDs will check the NIDs and aliases of nodes of all classes. NIDs need to be unique across all nodes, and
+// the aliases of compute nodes need to be unique and match their NID using the given alias format. If no alias format
+// is given, then the default nid%06d format is used.
+func AuditNIDs(allHardware map[string]sls_common.GenericHardware, aliasFormat string) (NIDAuditReport, error) {
+	report := NIDAuditReport{
+		DuplicateNIDs:    map[int][]string{},
+		DuplicateAliases: map[string][]string{},
+	}
+
+	nidAssignment := configs.NIDAssignment{AliasFormat: aliasFormat}
+
+	// Sort the xnames so the report is deterministic
+	var xnames []string
+	for xname := range allHardware {
+		xnames = append(xnames, xname)
+	}
+	sort.Strings(xnames)
+
+	nids := map[int][]string{}
+	aliases := map[string][]string{}
+	computeNIDs := map[int]bool{}
+	for _, xname := range xnames {
+		hardware := allHardware[xname]
+		if xnametypes.GetHMSType(hardware.Xname) != xnametypes.Node {
+			continue
+		}
+
+		nodeEP, err := decodeNodeExtraProperties(hardware)
+		if err != nil {
+			return NIDAuditReport{}, err
+		}
+
+		if nodeEP.NID != 0 {
+			nids[nodeEP.NID] = append(nids[nodeEP.NID], hardware.Xname)
+		}
+
+		if nodeEP.Role != "Compute" {
+			continue
+		}
+
+		for _, alias := range nodeEP.Aliases {
+			aliases[alias] = append(aliases[alias], hardware.Xname)
+		}
+
+		if nodeEP.NID == 0 {
+			report.AliasMismatches = append(report.AliasMismatches, NIDAliasMismatch{
+				Xname:   hardware.Xname,
+				Aliases: nodeEP.Aliases,
+			})
+			continue
+		}
+		computeNIDs[nodeEP.NID] = true
+
+		expectedAlias := nidAssignment.Alias(nodeEP.NID)
+		foundExpectedAlias := false
+		for _, alias := range nodeEP.Aliases {
+			if alias == expectedAlias {
+				foundExpectedAlias = true
+				break
+			}
+		}
+		if !foundExpectedAlias {
+			report.AliasMismatches = append(report.AliasMismatches, NIDAliasMismatch{
+				Xname:         hardware.Xname,
+				NID:           nodeEP.NID,
+				Aliases:       nodeEP.Aliases,
+				ExpectedAlias: expectedAlias,
+			})
+		}
+	}
+
+	for nid, xnames := range nids {
+		if len(xnames) > 1 {
+			report.DuplicateNIDs[nid] = xnames
+		}
+	}
+
+	for alias, xnames := range aliases {
+		if len(xnames) > 1 {
+			report.DuplicateAliases[alias] = xnames
+		}
+	}
+
+	// Find the gaps between the lowest and highest compute NIDs
+	var sortedComputeNIDs []int
+	for nid := range computeNIDs {
+		sortedComputeNIDs = append(sortedComputeNIDs, nid)
+	}
+	sort.Ints(sortedComputeNIDs)
+	for i := 1; i < len(sortedComputeNIDs); i++ {
+		if sortedComputeNIDs[i]-sortedComputeNIDs[i-1] > 1 {
+			report.Gaps = append(report.Gaps, NIDRange{
+				Start: sortedComputeNIDs[i-1] + 1,
+				End:   sortedComputeNIDs[i] - 1,
+			})
+		}
+	}
+
+	return report, nil
+}
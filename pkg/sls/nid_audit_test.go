@@ -0,0 +1,112 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package sls
+
+import (
+	"testing"
+
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+)
+
+type NIDAuditTestSuite struct {
+	suite.Suite
+}
+
+func (suite *NIDAuditTestSuite) TestAuditNIDs() {
+	allHardware := map[string]sls_common.GenericHardware{
+		// Mountain compute nodes
+		"x1000c0s0b0n0": {
+			Xname: "x1000c0s0b0n0",
+			Class: sls_common.ClassMountain,
+			ExtraPropertiesRaw: map[string]interface{}{
+				"Role": "Compute", "NID": 1000, "Aliases": []string{"nid001000"},
+			},
+		},
+		"x1000c0s0b0n1": {
+			Xname: "x1000c0s0b0n1",
+			Class: sls_common.ClassMountain,
+			ExtraPropertiesRaw: map[string]interface{}{
+				"Role": "Compute", "NID": 1001, "Aliases": []string{"nid001000"},
+			},
+		},
+
+		// River compute nodes
+		"x3000c0s25b1n0": sls_common.NewGenericHardware("x3000c0s25b1n0", sls_common.ClassRiver, sls_common.ComptypeNode{
+			Role: "Compute", NID: 1, Aliases: []string{"nid000001"},
+		}),
+		"x3000c0s25b4n0": sls_common.NewGenericHardware("x3000c0s25b4n0", sls_common.ClassRiver, sls_common.ComptypeNode{
+			Role: "Compute", NID: 1000, Aliases: []string{"nid001000"},
+		}),
+
+		// Management NCN
+		"x3000c0s1b0n0": sls_common.NewGenericHardware("x3000c0s1b0n0", sls_common.ClassRiver, sls_common.ComptypeNode{
+			Role: "Management", SubRole: "Master", NID: 100001, Aliases: []string{"ncn-m001"},
+		}),
+
+		// Non node hardware
+		"x3000c0w14": sls_common.NewGenericHardware("x3000c0w14", sls_common.ClassRiver, sls_common.ComptypeMgmtSwitch{}),
+	}
+
+	report, err := AuditNIDs(allHardware, "")
+	suite.NoError(err)
+	suite.True(report.HasProblems())
+
+	suite.Equal(map[int][]string{
+		1000: {"x1000c0s0b0n0", "x3000c0s25b4n0"},
+	}, report.DuplicateNIDs)
+	suite.Equal(map[string][]string{
+		"nid001000": {"x1000c0s0b0n0", "x1000c0s0b0n1", "x3000c0s25b4n0"},
+	}, report.DuplicateAliases)
+	suite.Equal([]NIDAliasMismatch{
+		{Xname: "x1000c0s0b0n1", NID: 1001, Aliases: []string{"nid001000"}, ExpectedAlias: "nid001001"},
+	}, report.AliasMismatches)
+	suite.Equal([]NIDRange{{Start: 2, End: 999}}, report.Gaps)
+
+	suite.Equal([]string{
+		"Duplicate NID 1000 used by x1000c0s0b0n0,x3000c0s25b4n0",
+		"Duplicate alias nid001000 used by x1000c0s0b0n0,x1000c0s0b0n1,x3000c0s25b4n0",
+		"Node x1000c0s0b0n1 with NID 1001 has aliases [nid001000] expected alias nid001001",
+		"Unused compute NIDs 2-999",
+	}, report.Summary())
+}
+
+func (suite *NIDAuditTestSuite) TestAuditNIDs_NoProblems() {
+	allHardware := map[string]sls_common.GenericHardware{
+		"x3000c0s25b1n0": sls_common.NewGenericHardware("x3000c0s25b1n0", sls_common.ClassRiver, sls_common.ComptypeNode{
+			Role: "Compute", NID: 1, Aliases: []string{"cn0001"},
+		}),
+		"x3000c0s25b2n0": sls_common.NewGenericHardware("x3000c0s25b2n0", sls_common.ClassRiver, sls_common.ComptypeNode{
+			Role: "Compute", NID: 2, Aliases: []string{"cn0002"},
+		}),
+	}
+
+	report, err := AuditNIDs(allHardware, "cn%04d")
+	suite.NoError(err)
+	suite.False(report.HasProblems())
+	suite.Empty(report.Summary())
+}
+
+func TestNIDAuditTestSuite(t *testing.T) {
+	suite.Run(t, new(NIDAuditTestSuite))
+}
@@ -0,0 +1,101 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package sls
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Cray-HPE/hardware-topology-assistant/pkg/diff"
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+)
+
+// FormatSubnet will format a subnet as text with one IP reservation per line sorted by IP address, so changes to
+// the subnet can be shown as a diff.
+func FormatSubnet(subnet sls_common.IPV4Subnet) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "cidr: %s\n", subnet.CIDR)
+	fmt.Fprintf(&sb, "vlan: %d\n", subnet.VlanID)
+	fmt.Fprintf(&sb, "gateway: %s\n", subnet.Gateway)
+	if subnet.DHCPStart != nil && subnet.DHCPEnd != nil {
+		fmt.Fprintf(&sb, "dhcp_start: %s\n", subnet.DHCPStart)
+		fmt.Fprintf(&sb, "dhcp_end: %s\n", subnet.DHCPEnd)
+	}
+
+	ipReservations := append([]sls_common.IPReservation{}, subnet.IPReservations...)
+	sort.SliceStable(ipReservations, func(i, j int) bool {
+		return bytes.Compare(ipReservations[i].IPAddress.To16(), ipReservations[j].IPAddress.To16()) < 0
+	})
+
+	sb.WriteString("reservations:\n")
+	for _, ipReservation := range ipReservations {
+		fmt.Fprintf(&sb, "  %s %s", ipReservation.IPAddress, ipReservation.Name)
+		if ipReservation.Comment != "" {
+			fmt.Fprintf(&sb, " (%s)", ipReservation.Comment)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// SubnetDiffs will generate a unified diff for each subnet that was added, removed, or changed between the existing
+// and modified version of a network. The diffs are ordered by subnet name.
+func SubnetDiffs(existing, modified sls_common.Network) (string, error) {
+	var existingEP, modifiedEP sls_common.NetworkExtraProperties
+	if err := DecodeNetworkExtraProperties(existing.ExtraPropertiesRaw, &existingEP); err != nil {
+		return "", fmt.Errorf("failed to decode extra properties of existing network (%s): %w", existing.Name, err)
+	}
+	if err := DecodeNetworkExtraProperties(modified.ExtraPropertiesRaw, &modifiedEP); err != nil {
+		return "", fmt.Errorf("failed to decode extra properties of modified network (%s): %w", modified.Name, err)
+	}
+
+	existingSubnets := map[string]string{}
+	modifiedSubnets := map[string]string{}
+	var subnetNames []string
+	for _, subnet := range existingEP.Subnets {
+		existingSubnets[subnet.Name] = FormatSubnet(subnet)
+		subnetNames = append(subnetNames, subnet.Name)
+	}
+	for _, subnet := range modifiedEP.Subnets {
+		modifiedSubnets[subnet.Name] = FormatSubnet(subnet)
+		if _, present := existingSubnets[subnet.Name]; !present {
+			subnetNames = append(subnetNames, subnet.Name)
+		}
+	}
+	sort.Strings(subnetNames)
+
+	var sb strings.Builder
+	for _, subnetName := range subnetNames {
+		subnetDiff, err := diff.Unified(fmt.Sprintf("%s/%s", modified.Name, subnetName), existingSubnets[subnetName], modifiedSubnets[subnetName])
+		if err != nil {
+			return "", fmt.Errorf("failed to diff subnet (%s) in network (%s): %w", subnetName, modified.Name, err)
+		}
+
+		sb.WriteString(subnetDiff)
+	}
+
+	return sb.String(), nil
+}
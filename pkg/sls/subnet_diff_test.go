@@ -0,0 +1,144 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package sls
+
+import (
+	"net"
+	"testing"
+
+	sls_common "github.com/Cray-HPE/hms-sls/pkg/sls-common"
+	"github.com/stretchr/testify/suite"
+)
+
+type SubnetDiffTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SubnetDiffTestSuite) bootstrapDHCP() sls_common.IPV4Subnet {
+	return sls_common.IPV4Subnet{
+		Name:      "bootstrap_dhcp",
+		CIDR:      "10.102.4.0/24",
+		VlanID:    6,
+		Gateway:   net.ParseIP("10.102.4.1"),
+		DHCPStart: net.ParseIP("10.102.4.12"),
+		DHCPEnd:   net.ParseIP("10.102.4.254"),
+		IPReservations: []sls_common.IPReservation{
+			{Name: "uan01", IPAddress: net.ParseIP("10.102.4.11"), Comment: "x3000c0s19b0n0"},
+			{Name: "can-switch-1", IPAddress: net.ParseIP("10.102.4.2")},
+		},
+	}
+}
+
+func (suite *SubnetDiffTestSuite) TestFormatSubnet() {
+	suite.Equal("cidr: 10.102.4.0/24\n"+
+		"vlan: 6\n"+
+		"gateway: 10.102.4.1\n"+
+		"dhcp_start: 10.102.4.12\n"+
+		"dhcp_end: 10.102.4.254\n"+
+		"reservations:\n"+
+		"  10.102.4.2 can-switch-1\n"+
+		"  10.102.4.11 uan01 (x3000c0s19b0n0)\n", FormatSubnet(suite.bootstrapDHCP()))
+}
+
+func (suite *SubnetDiffTestSuite) TestSubnetDiffs() {
+	existing := sls_common.Network{
+		Name: "CAN",
+		ExtraPropertiesRaw: map[string]interface{}{
+			"CIDR":    "10.102.4.0/24",
+			"Subnets": []sls_common.IPV4Subnet{suite.bootstrapDHCP()},
+		},
+	}
+
+	modifiedSubnet := suite.bootstrapDHCP()
+	modifiedSubnet.DHCPStart = net.ParseIP("10.102.4.13")
+	modifiedSubnet.IPReservations = append(modifiedSubnet.IPReservations,
+		sls_common.IPReservation{Name: "uan02", IPAddress: net.ParseIP("10.102.4.12"), Comment: "x3000c0s21b0n0"},
+	)
+	modified := sls_common.Network{
+		Name: "CAN",
+		ExtraPropertiesRaw: &sls_common.NetworkExtraProperties{
+			CIDR:    "10.102.4.0/24",
+			Subnets: []sls_common.IPV4Subnet{modifiedSubnet},
+		},
+	}
+
+	subnetDiffs, err := SubnetDiffs(existing, modified)
+	suite.NoError(err)
+	suite.Equal("--- a/CAN/bootstrap_dhcp\n"+
+		"+++ b/CAN/bootstrap_dhcp\n"+
+		"@@ -1,8 +1,9 @@\n"+
+		" cidr: 10.102.4.0/24\n"+
+		" vlan: 6\n"+
+		" gateway: 10.102.4.1\n"+
+		"-dhcp_start: 10.102.4.12\n"+
+		"+dhcp_start: 10.102.4.13\n"+
+		" dhcp_end: 10.102.4.254\n"+
+		" reservations:\n"+
+		"   10.102.4.2 can-switch-1\n"+
+		"   10.102.4.11 uan01 (x3000c0s19b0n0)\n"+
+		"+  10.102.4.12 uan02 (x3000c0s21b0n0)\n", subnetDiffs)
+}
+
+func (suite *SubnetDiffTestSuite) TestSubnetDiffs_SubnetAdded() {
+	existing := sls_common.Network{
+		Name:               "HMN_RVR",
+		ExtraPropertiesRaw: map[string]interface{}{"CIDR": "10.107.0.0/17"},
+	}
+	modified := sls_common.Network{
+		Name: "HMN_RVR",
+		ExtraPropertiesRaw: sls_common.NetworkExtraProperties{
+			CIDR: "10.107.0.0/17",
+			Subnets: []sls_common.IPV4Subnet{{
+				Name: "cabinet_3001", CIDR: "10.107.4.0/22", VlanID: 1514, Gateway: net.ParseIP("10.107.4.1"),
+			}},
+		},
+	}
+
+	subnetDiffs, err := SubnetDiffs(existing, modified)
+	suite.NoError(err)
+	suite.Equal("--- a/HMN_RVR/cabinet_3001\n"+
+		"+++ b/HMN_RVR/cabinet_3001\n"+
+		"@@ -0,0 +1,4 @@\n"+
+		"+cidr: 10.107.4.0/22\n"+
+		"+vlan: 1514\n"+
+		"+gateway: 10.107.4.1\n"+
+		"+reservations:\n", subnetDiffs)
+}
+
+func (suite *SubnetDiffTestSuite) TestSubnetDiffs_NoChanges() {
+	network := sls_common.Network{
+		Name: "CAN",
+		ExtraPropertiesRaw: map[string]interface{}{
+			"CIDR":    "10.102.4.0/24",
+			"Subnets": []sls_common.IPV4Subnet{suite.bootstrapDHCP()},
+		},
+	}
+
+	subnetDiffs, err := SubnetDiffs(network, network)
+	suite.NoError(err)
+	suite.Empty(subnetDiffs)
+}
+
+func TestSubnetDiffTestSuite(t *testing.T) {
+	suite.Run(t, new(SubnetDiffTestSuite))
+}